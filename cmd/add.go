@@ -1,15 +1,19 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
 	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/helpers"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
@@ -20,10 +24,27 @@ var addCmd = &cobra.Command{
 	Long: `Adds a new page based on the configuration.
 - Page name can include subdirectories (e.g., 'users/profile').
 - It can create multiple pages (eg., 'profile profile/settings').
+- With -i, an interactive form asks for the page, special files and template.
+- With --pack, every file of a template pack is rendered instead, asking for the
+  pack's variables unless given with --var key=value.
 `,
-	Args: cobra.MinimumNArgs(1),
+	Args: func(cmd *cobra.Command, args []string) error {
+		// The wizard asks for the page name itself
+		if interactive, _ := cmd.Flags().GetBool("interactive"); interactive {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		useClientFlag, _ := cmd.Flags().GetBool("use-client")
+		interactiveFlag, _ := cmd.Flags().GetBool("interactive")
+		dataFlag, _ := cmd.Flags().GetString("data")
+		asyncFlag, _ := cmd.Flags().GetBool("async")
+		metadataFlag, _ := cmd.Flags().GetString("metadata")
+		staticParamsFlag, _ := cmd.Flags().GetBool("static-params")
+		withTestsFlag, _ := cmd.Flags().GetBool("with-tests")
+		packFlag, _ := cmd.Flags().GetString("pack")
+		varFlags, _ := cmd.Flags().GetStringArray("var")
 
 		// Read Configuration
 		config, err := constants.LoadConfig()
@@ -33,6 +54,68 @@ var addCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		if dataFlag != "" {
+			if config.Router != constants.PagesRouter {
+				fmt.Fprintln(os.Stderr, "--data is only supported by the pages router.")
+				os.Exit(1)
+			}
+			if !slices.Contains(dataStrategies, dataFlag) {
+				fmt.Fprintf(os.Stderr, "Invalid data strategy '%s', expected one of: %s\n", dataFlag, strings.Join(dataStrategies, ", "))
+				os.Exit(1)
+			}
+		}
+
+		if config.Router != constants.AppRouter && (asyncFlag || metadataFlag != "" || staticParamsFlag || changedSegmentFlags(cmd)) {
+			fmt.Fprintln(os.Stderr, "--async, --metadata, --static-params and segment config flags are only supported by the app router.")
+			os.Exit(1)
+		}
+		if metadataFlag != "" && !slices.Contains(metadataKinds, metadataFlag) {
+			fmt.Fprintf(os.Stderr, "Invalid metadata '%s', expected one of: %s\n", metadataFlag, strings.Join(metadataKinds, ", "))
+			os.Exit(1)
+		}
+		if config.Router == constants.AppRouter && isClientPage(config, useClientFlag) {
+			if flags := serverOnlyFlags(cmd); len(flags) > 0 {
+				fmt.Fprintf(os.Stderr, "Client components (including pages styled with styled-components) cannot use %s.\n", strings.Join(flags, ", "))
+				os.Exit(1)
+			}
+		}
+		segment, err := segmentConfig(cmd, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if interactiveFlag {
+			if packFlag != "" {
+				fmt.Fprintln(os.Stderr, "--pack cannot be combined with -i.")
+				os.Exit(1)
+			}
+			runAddWizard(config, useClientFlag)
+			return
+		}
+
+		var packDir string
+		var pack *constants.Pack
+		var vars map[string]any
+		if packFlag != "" {
+			packDir, pack, err = resolvePack(AppFs, config, packFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading pack:\n%v\n", err)
+				os.Exit(1)
+			}
+			given, err := parseVarFlags(varFlags)
+			if err == nil {
+				vars, err = packVars(pack, given, promptPackVar(bufio.NewReader(os.Stdin), os.Stdout))
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		} else if len(varFlags) > 0 {
+			fmt.Fprintln(os.Stderr, "--var is only supported with --pack.")
+			os.Exit(1)
+		}
+
 		for i := range args {
 			pageNameInput := args[i]
 
@@ -44,17 +127,74 @@ var addCmd = &cobra.Command{
 			}
 
 			// Generate File Content
-			content, err := generatePageContent(pageComponentName, config, useClientFlag)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error generating page content:\n%v\n", err)
-				os.Exit(1)
+			data := newPageData(pageNameInput, pageComponentName, config, useClientFlag)
+			data.Data = dataFlag
+			if dataFlag == "isr" {
+				data.Revalidate = defaultRevalidate
+				if segment.Revalidate != nil {
+					data.Revalidate = *segment.Revalidate
+				}
+			}
+			if config.Router == constants.AppRouter {
+				data.Async = asyncFlag
+				data.Metadata = metadataFlag
+				data.StaticParams = staticParamsFlag
+				if !data.UseClient {
+					data.Segment = segment
+				}
 			}
 
-			// Create Directories and File
-			err = createPageFile(afero.NewOsFs(), targetPath, content)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error creating page file:\n%v\n", err)
-				os.Exit(1)
+			if pack != nil {
+				files, err := renderPack(AppFs, packDir, pack, filepath.Dir(targetPath), PackData{PageData: data, Vars: vars})
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error rendering pack:\n%v\n", err)
+					os.Exit(1)
+				}
+				for _, file := range files {
+					if err := createPageFile(AppFs, file.Path, file.Content); err != nil {
+						fmt.Fprintf(os.Stderr, "Error creating pack file:\n%v\n", err)
+						os.Exit(1)
+					}
+				}
+			} else {
+				content, err := generateFileContent("page", data)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error generating page content:\n%v\n", err)
+					os.Exit(1)
+				}
+
+				// Create Directories and File
+				err = createPageFile(afero.NewOsFs(), targetPath, content)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error creating page file:\n%v\n", err)
+					os.Exit(1)
+				}
+
+				stylesheets, err := pageStylesheet(AppFs, targetPath, data)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error generating stylesheet:\n%v\n", err)
+					os.Exit(1)
+				}
+				for _, file := range stylesheets {
+					if err := createPageFile(AppFs, file.Path, file.Content); err != nil {
+						fmt.Fprintf(os.Stderr, "Error creating stylesheet:\n%v\n", err)
+						os.Exit(1)
+					}
+				}
+			}
+
+			if withTestsFlag {
+				tests, err := scaffoldTests(config, pageNameInput, targetPath, data)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error generating tests:\n%v\n", err)
+					os.Exit(1)
+				}
+				for _, file := range tests {
+					if err := createPageFile(AppFs, file.Path, file.Content); err != nil {
+						fmt.Fprintf(os.Stderr, "Error creating test file:\n%v\n", err)
+						os.Exit(1)
+					}
+				}
 			}
 
 		}
@@ -94,7 +234,7 @@ func determinePathAndComponent(pageNameInput string, config *constants.Config) (
 		componentName += helpers.ToPascalCase(config.PageComponentSuffix)
 	}
 
-	var basePath string
+	basePath := config.RoutesDir()
 	var fileExtension string
 	var pageFileName string
 
@@ -105,23 +245,13 @@ func determinePathAndComponent(pageNameInput string, config *constants.Config) (
 		fileExtension = ".jsx"
 	}
 
-	// Determine base path and the actual filename used in the path structure
+	// Determine the actual filename used in the path structure
 	if config.Router == constants.AppRouter {
-		if config.SrcFolder {
-			basePath = filepath.Join("src", "app")
-		} else {
-			basePath = "app"
-		}
 		// App router always uses 'page.ext' in its leaf directory
 		pageFileName = "page" + fileExtension
 		// Construct path using the folder structure from input and the required filename
 		filePath = filepath.Join(basePath, pageNameInput, pageFileName)
 	} else { // pages router
-		if config.SrcFolder {
-			basePath = filepath.Join("src", "pages")
-		} else {
-			basePath = "pages"
-		}
 		// For pages router, always use 'index.ext' as the page file
 		pageFileName = "index" + fileExtension
 		filePath = filepath.Join(basePath, pageNameInput, pageFileName)
@@ -137,31 +267,218 @@ func determinePathAndComponent(pageNameInput string, config *constants.Config) (
 type PageData struct {
 	ComponentName string
 	Style         constants.ComponentStyleType
+	Language      constants.LanguageType
+	Router        constants.RouterType
+	SrcFolder     bool
 	UseClient     bool
+	// URL is the route the page is served at (e.g. "/blog/[slug]") and Segments its parts
+	URL      string
+	Segments []string
+	// Date is the generation date (YYYY-MM-DD) and GitUser the configured git user name
+	Date    string
+	GitUser string
+	// Body replaces the generated component when set (e.g. a migrated page)
+	Body string
+	// Params are the dynamic segments of the page URL
+	Params PageParams
+	// NextVersion is the Next.js version of the project, AsyncParams tells whether it passes params as Promises
+	NextVersion string
+	AsyncParams bool
+	// Data is the pages router data fetching strategy: "ssr", "ssg", "isr" or ""
+	Data       string
+	Revalidate int
+	// Async makes an app router page an async server component fetching its data
+	Async bool
+	// Metadata is the app router metadata export: "static", "dynamic" (generateMetadata) or ""
+	Metadata     string
+	StaticParams bool
+	Segment      constants.SegmentConfig
+	// Styling is the configured styling approach, StylesFile the stylesheet imported by the page (e.g. "page.module.css")
+	Styling    constants.StylingType
+	StylesFile string
+}
+
+// TakesParams reports whether the app router page receives the params of its dynamic segments
+func (d PageData) TakesParams() bool {
+	return d.Router == constants.AppRouter && len(d.Params) > 0
+}
+
+// AsyncComponent reports whether the page is an async component, either to fetch its data or to await its params
+func (d PageData) AsyncComponent() bool {
+	return d.Async || (d.TakesParams() && d.AsyncParams && !d.UseClient)
+}
+
+// PageParam is a dynamic segment of the page URL
+type PageParam struct {
+	Name     string
+	CatchAll bool
+	Optional bool
+}
+
+// PageParams are the dynamic segments of a URL
+type PageParams []PageParam
+
+// Type returns the TypeScript type of the params object, e.g. "{ slug: string; 'post-id'?: string[] }"
+func (p PageParams) Type() string {
+	var fields []string
+	for _, param := range p {
+		field := propertyName(param.Name)
+		if param.Optional {
+			field += "?"
+		}
+		if param.CatchAll {
+			field += ": string[]"
+		} else {
+			field += ": string"
+		}
+		fields = append(fields, field)
+	}
+	return "{ " + strings.Join(fields, "; ") + " }"
+}
+
+// Names returns the comma separated params for destructuring, renaming the ones that are not
+// valid identifiers, e.g. "slug, 'post-id': postId"
+func (p PageParams) Names() string {
+	var names []string
+	for _, param := range p {
+		if local := param.Local(); local != param.Name {
+			names = append(names, propertyName(param.Name)+": "+local)
+		} else {
+			names = append(names, param.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Value returns the expression rendering the destructured param as text, e.g. "rest.join('/')"
+func (p PageParam) Value() string {
+	switch {
+	case p.Optional:
+		return p.Local() + "?.join('/') ?? ''"
+	case p.CatchAll:
+		return p.Local() + ".join('/')"
+	}
+	return p.Local()
+}
+
+// Local returns the variable name the param is destructured into, e.g. "postId" for "post-id"
+func (p PageParam) Local() string {
+	if identifierPattern.MatchString(p.Name) {
+		return p.Name
+	}
+	local := helpers.ToCamelCase(helpers.ToKebabCase(p.Name))
+	if !identifierPattern.MatchString(local) {
+		local = "_" + local
+	}
+	return local
+}
+
+// Pages router data fetching strategies for the --data flag
+var dataStrategies = []string{"ssr", "ssg", "isr"}
+
+// App router metadata exports for the --metadata flag
+var metadataKinds = []string{"static", "dynamic"}
+
+// Seconds after which ISR pages are regenerated
+const defaultRevalidate = 60
+
+// projectInfo is what templates learn about the project outside of the config
+type projectInfo struct {
+	NextVersion string
+	GitUser     string
+}
+
+// loadProjectInfo reads the Next.js version and the git user name, which takes a file read and a git call
+func loadProjectInfo(config *constants.Config) projectInfo {
+	return projectInfo{NextVersion: nextVersion(AppFs, config), GitUser: helpers.GitUser()}
+}
+
+// newPageData returns the page template data for the page name input
+func newPageData(pageNameInput string, componentName string, config *constants.Config, useClient bool) PageData {
+	return projectPageData(pageNameInput, componentName, config, useClient, loadProjectInfo(config))
+}
+
+// projectPageData is newPageData with the project info already loaded
+func projectPageData(pageNameInput string, componentName string, config *constants.Config, useClient bool, info projectInfo) PageData {
+	url := routeURL(config, pageNameInput)
+	data := PageData{
+		ComponentName: componentName,
+		Style:         config.ComponentStyle,
+		Language:      config.Language,
+		Router:        config.Router,
+		SrcFolder:     config.SrcFolder,
+		URL:           url,
+		Segments:      routes.SplitURL(url),
+		Params:        urlParams(url),
+		Date:          time.Now().Format(time.DateOnly),
+		GitUser:       info.GitUser,
+		NextVersion:   info.NextVersion,
+		AsyncParams:   usesAsyncParams(info.NextVersion),
+		Styling:       config.Styling,
+	}
+	if config.Router == constants.AppRouter {
+		data.UseClient = isClientPage(config, useClient)
+		// Segment config exports are only allowed in server components
+		if !data.UseClient {
+			data.Segment = config.SegmentConfig
+		}
+	}
+	if ext := config.Styling.StylesheetExtension(); ext != "" {
+		data.StylesFile = "index" + ext
+		if config.Router == constants.AppRouter {
+			data.StylesFile = "page" + ext
+		}
+	}
+	return data
+}
+
+// pageStylesheet returns the stylesheet imported by the page when it doesn't exist yet
+func pageStylesheet(fs afero.Fs, pagePath string, data PageData) ([]GeneratedFile, error) {
+	if data.StylesFile == "" || data.Body != "" {
+		return nil, nil
+	}
+	path := filepath.Join(filepath.Dir(pagePath), data.StylesFile)
+	if exists, _ := afero.Exists(fs, path); exists {
+		return nil, nil
+	}
+	content, err := generateFileContent("component-styles", data)
+	if err != nil {
+		return nil, err
+	}
+	return []GeneratedFile{{Path: path, Content: content}}, nil
+}
+
+// urlParams returns the dynamic segments of the URL
+func urlParams(url string) PageParams {
+	var params PageParams
+	for _, raw := range routes.SplitURL(url) {
+		if seg, err := routes.ParseSegment(raw); err == nil && seg.Dynamic {
+			params = append(params, PageParam{Name: seg.Name, CatchAll: seg.CatchAll, Optional: seg.Optional})
+		}
+	}
+	return params
 }
 
-// generatePageContent creates the basic component code
-func generatePageContent(componentName string, config *constants.Config, useClient bool) (string, error) {
+// generateFileContent renders the named template from the templates directory with the given data
+func generateFileContent(name string, data any) (string, error) {
 	// Load the external template file
-	tmplPath := "cmd/templates/page.tmpl"
+	tmplPath := "cmd/templates/" + name + ".tmpl"
 	tmplContent, err := afero.ReadFile(AppFs, tmplPath)
 	if err != nil {
 		return "", fmt.Errorf("error reading template file: %w", err)
 	}
 
-	// Parse the template
-	tmpl, err := template.New("page").Parse(string(tmplContent))
+	return renderTemplate(name, string(tmplContent), data)
+}
+
+// renderTemplate parses and executes the template text with the template functions
+func renderTemplate(name string, text string, data any) (string, error) {
+	// Parse the template, a mistyped map key fails instead of rendering "<no value>"
+	tmpl, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
 	if err != nil {
 		return "", fmt.Errorf("error parsing template: %w", err)
 	}
 
-	// Prepare the data
-	data := PageData{
-		ComponentName: componentName,
-		Style:         config.ComponentStyle,
-		UseClient:     config.Router == constants.AppRouter && useClient,
-	}
-
 	// Execute the template
 	var output bytes.Buffer
 	if err := tmpl.Execute(&output, data); err != nil {
@@ -171,6 +488,52 @@ func generatePageContent(componentName string, config *constants.Config, useClie
 	return output.String(), nil
 }
 
+// segmentConfig merges the segment config flags over the config defaults
+func segmentConfig(cmd *cobra.Command, config *constants.Config) (constants.SegmentConfig, error) {
+	segment := config.SegmentConfig
+	if cmd.Flags().Changed("dynamic") {
+		segment.Dynamic, _ = cmd.Flags().GetString("dynamic")
+	}
+	if cmd.Flags().Changed("revalidate") {
+		revalidate, _ := cmd.Flags().GetInt("revalidate")
+		segment.Revalidate = &revalidate
+	}
+	if cmd.Flags().Changed("runtime") {
+		segment.Runtime, _ = cmd.Flags().GetString("runtime")
+	}
+	if cmd.Flags().Changed("fetch-cache") {
+		segment.FetchCache, _ = cmd.Flags().GetString("fetch-cache")
+	}
+	return segment, segment.Validate()
+}
+
+// isClientPage reports whether an app router page is a client component;
+// styled-components only work in client components.
+func isClientPage(config *constants.Config, useClient bool) bool {
+	return useClient || config.Styling == constants.StyledComponents
+}
+
+// serverOnlyFlags returns the given flags that only apply to server components
+func serverOnlyFlags(cmd *cobra.Command) []string {
+	var flags []string
+	for _, name := range []string{"async", "metadata", "static-params", "dynamic", "revalidate", "runtime", "fetch-cache"} {
+		if cmd.Flags().Changed(name) {
+			flags = append(flags, "--"+name)
+		}
+	}
+	return flags
+}
+
+// changedSegmentFlags reports whether any app router only segment config flag was given
+func changedSegmentFlags(cmd *cobra.Command) bool {
+	for _, name := range []string{"dynamic", "runtime", "fetch-cache"} {
+		if cmd.Flags().Changed(name) {
+			return true
+		}
+	}
+	return false
+}
+
 // createPageFile ensures directories exist and writes the file
 func createPageFile(fs afero.Fs, targetPath string, content string) error {
 	// Ensure directory exists
@@ -190,4 +553,16 @@ func createPageFile(fs afero.Fs, targetPath string, content string) error {
 func init() {
 	rootCmd.AddCommand(addCmd)
 	addCmd.Flags().Bool("use-client", false, "Use 'use client' directive for the component (only for app router)")
+	addCmd.Flags().BoolP("interactive", "i", false, "Add the page through an interactive form")
+	addCmd.Flags().String("pack", "", "Render the files of a template pack, by name under the packs directory or by path")
+	addCmd.Flags().StringArray("var", nil, "Set a pack variable, e.g. --var entity=product (repeatable)")
+	addCmd.Flags().Bool("with-tests", false, "Also generate a unit test and a Playwright spec for the page")
+	addCmd.Flags().String("data", "", "Generate data fetching for the page: ssr, ssg or isr (only for pages router)")
+	addCmd.Flags().Bool("async", false, "Generate an async server component with a fetch stub (only for app router)")
+	addCmd.Flags().String("metadata", "", "Generate a static 'metadata' export or 'generateMetadata': static or dynamic (only for app router)")
+	addCmd.Flags().Bool("static-params", false, "Generate 'generateStaticParams' for dynamic segments (only for app router)")
+	addCmd.Flags().String("dynamic", "", "Segment config 'dynamic' export: "+strings.Join(constants.DynamicValues, ", "))
+	addCmd.Flags().Int("revalidate", 0, "Segment config 'revalidate' export in seconds, or the ISR interval with --data isr")
+	addCmd.Flags().String("runtime", "", "Segment config 'runtime' export: "+strings.Join(constants.RuntimeValues, ", "))
+	addCmd.Flags().String("fetch-cache", "", "Segment config 'fetchCache' export: "+strings.Join(constants.FetchCacheValues, ", "))
 }
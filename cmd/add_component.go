@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/helpers"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var pascalCasePattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+var addComponentCmd = &cobra.Command{
+	Use:   "add-component [component-name] --flag",
+	Short: "Adds a new component to your Next.js project.",
+	Long: `Adds a new component based on the configuration.
+- Components are created in the components directory (componentsDir in the config, 'components' by default).
+- Component name can include subdirectories (e.g., 'ui/Button').
+- Use --route to colocate the component in the '_components' folder of a route (only for app router).
+- Use --styles, --test, --stories and --index to also generate a CSS module, a test, a story and an index barrel.
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		routeFlag, _ := cmd.Flags().GetString("route")
+		var options ComponentOptions
+		options.UseClient, _ = cmd.Flags().GetBool("use-client")
+		options.Styles, _ = cmd.Flags().GetBool("styles")
+		options.Test, _ = cmd.Flags().GetBool("test")
+		options.Stories, _ = cmd.Flags().GetBool("stories")
+		options.Index, _ = cmd.Flags().GetBool("index")
+
+		config, err := constants.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+			fmt.Fprintln(os.Stderr, "Please run 'nextjs-routing-helper-cli init' first.")
+			os.Exit(1)
+		}
+		if routeFlag != "" && config.Router != constants.AppRouter {
+			fmt.Fprintln(os.Stderr, "--route is only supported by the app router, every file in the pages directory is a route.")
+			os.Exit(1)
+		}
+
+		for _, componentNameInput := range args {
+			files, err := scaffoldComponent(AppFs, config, componentNameInput, routeFlag, options)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error generating component:\n%v\n", err)
+				os.Exit(1)
+			}
+			for _, file := range files {
+				if err := createPageFile(AppFs, file.Path, file.Content); err != nil {
+					fmt.Fprintf(os.Stderr, "Error creating component file:\n%v\n", err)
+					os.Exit(1)
+				}
+			}
+			fmt.Printf("Successfully created a component: \n- %s\n", componentNameInput)
+			for _, file := range files {
+				fmt.Printf("  %s\n", file.Path)
+			}
+		}
+	},
+}
+
+// ComponentOptions are the optional files generated along with a component
+type ComponentOptions struct {
+	UseClient bool
+	Styles    bool
+	Test      bool
+	Stories   bool
+	Index     bool
+}
+
+// ComponentData holds the dynamic data for the component templates
+type ComponentData struct {
+	ComponentName string
+	Style         constants.ComponentStyleType
+	Language      constants.LanguageType
+	UseClient     bool
+	Styles        bool
+	// StoryTitle is the Storybook title, e.g. "Components/Ui/Button"
+	StoryTitle    string
+	TestFramework string
+}
+
+// componentDir returns the folder of a component, in the components directory or in the '_components' folder of a route.
+func componentDir(config *constants.Config, componentNameInput string, route string) string {
+	root := config.ComponentsRoot()
+	if route != "" {
+		root = filepath.Join(config.RoutesDir(), filepath.FromSlash(strings.Trim(route, "/")), "_components")
+	}
+	return filepath.Join(root, filepath.FromSlash(componentNameInput))
+}
+
+// scaffoldComponent renders the component and the optional files asked for. Existing components are never overwritten.
+func scaffoldComponent(fs afero.Fs, config *constants.Config, componentNameInput string, route string, options ComponentOptions) ([]GeneratedFile, error) {
+	input := strings.Trim(filepath.ToSlash(componentNameInput), "/")
+	if input == "" {
+		return nil, fmt.Errorf("component name cannot be empty or just slashes")
+	}
+	parts := strings.Split(input, "/")
+	name := parts[len(parts)-1]
+	// Names already in PascalCase (e.g. "PostCard") are kept as typed
+	if !pascalCasePattern.MatchString(name) {
+		name = helpers.ToPascalCase(name)
+	}
+	parts[len(parts)-1] = name
+	dir := componentDir(config, strings.Join(parts, "/"), route)
+
+	titleParts := []string{"Components"}
+	for _, part := range parts[:len(parts)-1] {
+		titleParts = append(titleParts, helpers.ToPascalCase(part))
+	}
+	titleParts = append(titleParts, name)
+	data := ComponentData{
+		ComponentName: name,
+		Style:         config.ComponentStyle,
+		Language:      config.Language,
+		UseClient:     config.Router == constants.AppRouter && options.UseClient,
+		Styles:        options.Styles,
+		StoryTitle:    strings.Join(titleParts, "/"),
+		TestFramework: config.Testing.TestFramework(),
+	}
+
+	componentExt := componentExtension(config)
+	outputs := []struct {
+		enabled  bool
+		template string
+		file     string
+	}{
+		{true, "component", name + componentExt},
+		{options.Styles, "component-styles", name + ".module.css"},
+		{options.Test, "component-test", name + ".test" + componentExt},
+		{options.Stories, "component-stories", name + ".stories" + componentExt},
+		{options.Index, "component-index", "index" + scriptExtension(config)},
+	}
+
+	var files []GeneratedFile
+	for _, output := range outputs {
+		if !output.enabled {
+			continue
+		}
+		path := filepath.Join(dir, output.file)
+		if exists, _ := afero.Exists(fs, path); exists {
+			return nil, fmt.Errorf("file '%s' already exists", path)
+		}
+		content, err := generateFileContent(output.template, data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, GeneratedFile{Path: path, Content: content})
+	}
+	return files, nil
+}
+
+func init() {
+	rootCmd.AddCommand(addComponentCmd)
+	addComponentCmd.Flags().String("route", "", "Colocate the component in the '_components' folder of this route (only for app router)")
+	addComponentCmd.Flags().Bool("use-client", false, "Use 'use client' directive for the component (only for app router)")
+	addComponentCmd.Flags().Bool("styles", false, "Also generate a CSS module")
+	addComponentCmd.Flags().Bool("test", false, "Also generate a test file")
+	addComponentCmd.Flags().Bool("stories", false, "Also generate a Storybook story")
+	addComponentCmd.Flags().Bool("index", false, "Also generate an index barrel")
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaffoldComponent(t *testing.T) {
+	fs := useTemplateFs(t)
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: constants.Const, SrcFolder: true}
+
+	files, err := scaffoldComponent(fs, config, "ui/button", "", ComponentOptions{Styles: true, Test: true, Stories: true, Index: true})
+	assert.NoError(t, err)
+	dir := filepath.Join("src", "components", "ui", "Button")
+	paths := make(map[string]string)
+	for _, file := range files {
+		paths[file.Path] = file.Content
+	}
+	assert.Len(t, paths, 5)
+	assert.Equal(t, `import styles from './Button.module.css';
+
+type ButtonProps = {
+  children?: React.ReactNode;
+};
+
+const Button = ({ children }: ButtonProps) => {
+  return <div className={styles.root}>{children}</div>;
+};
+
+export default Button;`, paths[filepath.Join(dir, "Button.tsx")])
+	assert.Contains(t, paths, filepath.Join(dir, "Button.module.css"))
+	assert.Contains(t, paths[filepath.Join(dir, "Button.test.tsx")], "render(<Button>Hello</Button>);")
+	assert.Contains(t, paths[filepath.Join(dir, "Button.stories.tsx")], "title: 'Components/Ui/Button',")
+	assert.Contains(t, paths[filepath.Join(dir, "index.ts")], "export { default as Button } from './Button';")
+
+	config = &constants.Config{Router: constants.AppRouter, Language: constants.Javascript, ComponentStyle: constants.Function}
+	files, err = scaffoldComponent(fs, config, "PostCard", "blog/[slug]", ComponentOptions{UseClient: true})
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, filepath.Join("app", "blog", "[slug]", "_components", "PostCard", "PostCard.jsx"), files[0].Path)
+	assert.Equal(t, `'use client';
+
+export default function PostCard({ children }) {
+  return <div>{children}</div>;
+}`, files[0].Content)
+
+	assert.NoError(t, afero.WriteFile(fs, files[0].Path, []byte(files[0].Content), 0644))
+	_, err = scaffoldComponent(fs, config, "PostCard", "blog/[slug]", ComponentOptions{})
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -52,6 +53,57 @@ func TestDeterminePathAndComponent(t *testing.T) {
 			expectedTarget: filepath.Join("src", "app", "products", "details", "page.jsx"),
 			expectedName:   "DetailsComponent",
 		},
+		{
+			// Characters that can't be part of an identifier separate words
+			configRouter:              "app",
+			configLanguage:            "ts",
+			configComponentStyle:      "function",
+			configPageComponentSuffix: "page",
+
+			inputPath:      "blog/[slug]",
+			expectedTarget: filepath.Join("app", "blog", "[slug]", "page.tsx"),
+			expectedName:   "SlugPage",
+		},
+		{
+			configRouter:              "app",
+			configLanguage:            "ts",
+			configComponentStyle:      "function",
+			configPageComponentSuffix: "page",
+
+			inputPath:      "docs/[[...rest]]",
+			expectedTarget: filepath.Join("app", "docs", "[[...rest]]", "page.tsx"),
+			expectedName:   "RestPage",
+		},
+		{
+			configRouter:              "app",
+			configLanguage:            "ts",
+			configComponentStyle:      "function",
+			configPageComponentSuffix: "page",
+
+			inputPath:      "user-profile",
+			expectedTarget: filepath.Join("app", "user-profile", "page.tsx"),
+			expectedName:   "UserProfilePage",
+		},
+		{
+			configRouter:              "app",
+			configLanguage:            "ts",
+			configComponentStyle:      "function",
+			configPageComponentSuffix: "page",
+
+			inputPath:      "user_settings",
+			expectedTarget: filepath.Join("app", "user_settings", "page.tsx"),
+			expectedName:   "UserSettingsPage",
+		},
+		{
+			configRouter:              "app",
+			configLanguage:            "ts",
+			configComponentStyle:      "function",
+			configPageComponentSuffix: "page",
+
+			inputPath:      "release.notes",
+			expectedTarget: filepath.Join("app", "release.notes", "page.tsx"),
+			expectedName:   "ReleaseNotesPage",
+		},
 	}
 
 	for _, tt := range tests {
@@ -70,3 +122,30 @@ func TestDeterminePathAndComponent(t *testing.T) {
 		assert.Equal(t, tt.expectedName, componentName, "unexpected component name")
 	}
 }
+
+func TestClientPageServerOnlyOptions(t *testing.T) {
+	useTemplateFs(t)
+
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: constants.Function}
+	assert.False(t, isClientPage(config, false))
+	assert.True(t, isClientPage(config, true))
+	config.Styling = constants.StyledComponents
+	assert.True(t, isClientPage(config, false))
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("static-params", false, "")
+	cmd.Flags().Int("revalidate", 0, "")
+	cmd.Flags().String("runtime", "", "")
+	assert.NoError(t, cmd.ParseFlags([]string{"--static-params", "--revalidate", "60"}))
+	assert.Equal(t, []string{"--static-params", "--revalidate"}, serverOnlyFlags(cmd))
+
+	// Segment config defaults are left out of client pages
+	revalidate := 60
+	config = &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: constants.Function, SegmentConfig: constants.SegmentConfig{Revalidate: &revalidate}}
+	assert.False(t, newPageData("blog/[slug]", "SlugPage", config, false).Segment.IsZero())
+	data := newPageData("blog/[slug]", "SlugPage", config, true)
+	assert.True(t, data.Segment.IsZero())
+	content, err := generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.NotContains(t, content, "export const revalidate")
+}
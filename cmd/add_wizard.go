@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	wizardui "github.com/bllakcn/nextjs-routing-helper-cli/cmd/ui/wizard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/afero"
+)
+
+// runAddWizard collects the page details through the TUI and creates the files once confirmed.
+func runAddWizard(config *constants.Config, useClient bool) {
+	// The preview renders on every frame, so the project info is only read once
+	info := loadProjectInfo(config)
+	options := wizardui.Options{
+		Folders:     routeFolders(AppFs, config),
+		AllowClient: config.Router == constants.AppRouter,
+		Templates:   pageTemplates(AppFs),
+		Preview: func(choices wizardui.Choices) string {
+			files, err := scaffoldPage(AppFs, config, choices, info)
+			if err != nil {
+				return fmt.Sprintf("Error: %v", err)
+			}
+			var b strings.Builder
+			for _, file := range files {
+				b.WriteString(fmt.Sprintf("── %s ──\n%s\n\n", file.Path, file.Content))
+			}
+			return strings.TrimRight(b.String(), "\n")
+		},
+	}
+	if config.Router == constants.AppRouter {
+		options.Special = routes.AppSpecialFiles
+	}
+
+	m := wizardui.New(options)
+	if useClient {
+		m = m.WithUseClient(true)
+	}
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error running program: %v\n", err)
+		os.Exit(1)
+	}
+
+	choices, confirmed := final.(wizardui.Model).Result()
+	if !confirmed {
+		fmt.Println("Cancelled, nothing was written.")
+		return
+	}
+
+	files, err := scaffoldPage(AppFs, config, choices, info)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating page content:\n%v\n", err)
+		os.Exit(1)
+	}
+	for _, file := range files {
+		if err := createPageFile(AppFs, file.Path, file.Content); err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating page file:\n%v\n", err)
+			os.Exit(1)
+		}
+	}
+	fmt.Printf("Successfully created a page: \n- %s\n", choices.Path)
+	for _, file := range files {
+		fmt.Printf("  %s\n", file.Path)
+	}
+}
+
+// scaffoldPage renders the page and the special files the wizard choices ask for. Existing special files are kept.
+func scaffoldPage(fs afero.Fs, config *constants.Config, choices wizardui.Choices, info projectInfo) ([]GeneratedFile, error) {
+	targetPath, componentName, err := determinePathAndComponent(choices.Path, config)
+	if err != nil {
+		return nil, err
+	}
+	template := choices.Template
+	if template == "" {
+		template = "page"
+	}
+	data := projectPageData(choices.Path, componentName, config, choices.UseClient, info)
+	content, err := generateFileContent(template, data)
+	if err != nil {
+		return nil, err
+	}
+	files := []GeneratedFile{{Path: targetPath, Content: content}}
+	stylesheets, err := pageStylesheet(fs, targetPath, data)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, stylesheets...)
+
+	dir := filepath.Dir(targetPath)
+	for _, name := range choices.Special {
+		if routes.FindSource(fs, dir, name) != "" {
+			continue
+		}
+		content, err := renderSpecialFile(name, dir, config, info.NextVersion)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, GeneratedFile{Path: specialFilePath(config, dir, name), Content: content})
+	}
+	return files, nil
+}
+
+// routeFolders lists the folders under the routes directory as 'add' style paths ("blog/", "blog/[slug]/").
+func routeFolders(fs afero.Fs, config *constants.Config) []string {
+	var folders []string
+	baseDir := config.RoutesDir()
+	_ = afero.Walk(fs, baseDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() || path == baseDir {
+			return nil
+		}
+		if routes.IsPrivate(info.Name()) {
+			return filepath.SkipDir
+		}
+		rel, _ := filepath.Rel(baseDir, path)
+		folders = append(folders, filepath.ToSlash(rel)+"/")
+		return nil
+	})
+	sort.Strings(folders)
+	return folders
+}
+
+// pageTemplates lists the templates usable for pages, "page" first. Component and test templates are left out.
+func pageTemplates(fs afero.Fs) []string {
+	reserved := map[string]bool{"page": true, "route": true}
+	for _, name := range routes.AppSpecialFiles {
+		reserved[name] = true
+	}
+
+	templates := []string{"page"}
+	entries, _ := afero.ReadDir(fs, "cmd/templates")
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".tmpl")
+		if entry.IsDir() || name == entry.Name() || reserved[name] || strings.HasPrefix(name, "component") || strings.HasPrefix(name, "test-") {
+			continue
+		}
+		templates = append(templates, name)
+	}
+	return templates
+}
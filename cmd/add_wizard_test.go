@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	wizardui "github.com/bllakcn/nextjs-routing-helper-cli/cmd/ui/wizard"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaffoldPage(t *testing.T) {
+	fs := useTemplateFs(t)
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: constants.Function, PageComponentSuffix: "page"}
+	assert.NoError(t, afero.WriteFile(fs, "package.json", []byte(`{"dependencies": {"next": "14.2.0"}}`), 0644))
+
+	// The project info loaded when the wizard starts is used, package.json is not read again
+	info := projectInfo{NextVersion: "15.0.0"}
+	files, err := scaffoldPage(fs, config, wizardui.Choices{Path: "blog/[slug]", Special: []string{"layout"}}, info)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Equal(t, filepath.Join("app", "blog", "[slug]", "page.tsx"), files[0].Path)
+	assert.Contains(t, files[0].Content, "params: Promise<{ slug: string }>;")
+	assert.Contains(t, files[1].Content, "params: Promise<{ slug: string }>")
+}
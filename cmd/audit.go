@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// Fixes an audit finding can suggest.
+const (
+	fixAddClient    = "add"
+	fixRemoveClient = "remove"
+)
+
+// Special files that must always be client components.
+var clientOnlyFiles = []string{"error", "global-error"}
+
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audits the source files of your Next.js project.",
+}
+
+var auditClientCmd = &cobra.Command{
+	Use:   "client --flag",
+	Short: "Audits the client/server component boundaries of your routes.",
+	Long: `Scans every component under the app directory and reports:
+- Client-only hooks, event handlers, browser APIs or contexts used without 'use client'.
+- 'use client' files that use none of them.
+- Async components marked 'use client'.
+- Error boundaries missing 'use client'.
+Use --fix to add the directive where it is missing. Removing it is only suggested, as components can
+still need it for client-only libraries or function props; use --fix-remove to apply those suggestions too.
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fixFlag, _ := cmd.Flags().GetBool("fix")
+		fixRemoveFlag, _ := cmd.Flags().GetBool("fix-remove")
+
+		config, err := constants.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+			os.Exit(1)
+		}
+		if config.Router != constants.AppRouter {
+			fmt.Fprintln(os.Stderr, "Client components are only supported by the app router.")
+			os.Exit(1)
+		}
+
+		findings, err := auditClient(AppFs, config.RoutesDir())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error auditing components:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		if fixFlag || fixRemoveFlag {
+			fixed, remaining, err := applyClientFixes(AppFs, findings, fixFlag, fixRemoveFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error applying fixes:\n%v\n", err)
+				os.Exit(1)
+			}
+			for _, finding := range fixed {
+				fmt.Printf("Fixed %s\n", finding.File)
+			}
+			findings = remaining
+		}
+
+		if len(findings) == 0 {
+			fmt.Println("No client component issues found.")
+			return
+		}
+		for _, finding := range findings {
+			fmt.Fprintln(os.Stderr, finding)
+		}
+		fmt.Fprintf(os.Stderr, "%d client component issue(s) found.\n", len(findings))
+		os.Exit(1)
+	},
+}
+
+// ClientFinding is a misplaced or missing 'use client' directive.
+type ClientFinding struct {
+	File    string
+	Message string
+	// Fix is the change to the directive that resolves the finding, empty when it needs a manual fix
+	Fix string
+}
+
+func (f ClientFinding) String() string {
+	switch f.Fix {
+	case fixAddClient:
+		return fmt.Sprintf("%s: %s (fix: add 'use client')", f.File, f.Message)
+	case fixRemoveClient:
+		return fmt.Sprintf("%s: %s (fix: remove 'use client')", f.File, f.Message)
+	}
+	return fmt.Sprintf("%s: %s", f.File, f.Message)
+}
+
+// auditClient checks the 'use client' directive of every component under the routes directory.
+func auditClient(fs afero.Fs, routesDir string) ([]ClientFinding, error) {
+	var findings []ClientFinding
+	err := routes.WalkSources(fs, routesDir, func(path string) error {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		if name == "route" || name == "middleware" {
+			return nil
+		}
+		content, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return err
+		}
+		if finding, ok := auditClientFile(path, name, content); ok {
+			findings = append(findings, finding)
+		}
+		return nil
+	})
+	return findings, err
+}
+
+// auditClientFile returns the finding of a single component file, if any.
+func auditClientFile(path, name string, content []byte) (ClientFinding, bool) {
+	if routes.HasDirective(content, "use server") {
+		return ClientFinding{}, false
+	}
+	client := routes.IsClientComponent(content)
+	async := routes.HasAsyncComponent(content)
+	clientOnly := slices.Contains(clientOnlyFiles, name)
+
+	if !client {
+		// Custom hooks may well be server safe, so only well known client APIs are flagged here
+		features := routes.ClientFeatures(content, false)
+		switch {
+		case clientOnly:
+			return ClientFinding{File: path, Message: "error boundaries must be client components", Fix: fixAddClient}, true
+		case len(features) > 0 && async:
+			return ClientFinding{File: path, Message: fmt.Sprintf("async server component uses %s; move them into a client component", strings.Join(features, ", "))}, true
+		case len(features) > 0:
+			return ClientFinding{File: path, Message: fmt.Sprintf("uses %s without 'use client'", strings.Join(features, ", ")), Fix: fixAddClient}, true
+		}
+		return ClientFinding{}, false
+	}
+
+	features := routes.ClientFeatures(content, true)
+	switch {
+	case async && (len(features) > 0 || clientOnly):
+		return ClientFinding{File: path, Message: "async components cannot be client components; split the interactive parts into a client component"}, true
+	case async:
+		return ClientFinding{File: path, Message: "async components cannot be client components", Fix: fixRemoveClient}, true
+	case len(features) == 0 && !clientOnly:
+		return ClientFinding{File: path, Message: "'use client' is not needed, no hooks, event handlers, browser APIs or contexts are used", Fix: fixRemoveClient}, true
+	}
+	return ClientFinding{}, false
+}
+
+// applyClientFixes rewrites the directive of the findings whose fix is enabled and returns the fixed
+// and the remaining findings.
+func applyClientFixes(fs afero.Fs, findings []ClientFinding, add, remove bool) ([]ClientFinding, []ClientFinding, error) {
+	var fixed, remaining []ClientFinding
+	for i, finding := range findings {
+		if !((finding.Fix == fixAddClient && add) || (finding.Fix == fixRemoveClient && remove)) {
+			remaining = append(remaining, finding)
+			continue
+		}
+		content, err := afero.ReadFile(fs, finding.File)
+		if err != nil {
+			return fixed, append(remaining, findings[i:]...), err
+		}
+		updated := routes.RemoveDirective(string(content), "use client")
+		if finding.Fix == fixAddClient {
+			updated = routes.AddDirective(string(content), "use client")
+		}
+		if err := afero.WriteFile(fs, finding.File, []byte(updated), 0644); err != nil {
+			return fixed, append(remaining, findings[i:]...), err
+		}
+		fixed = append(fixed, finding)
+	}
+	return fixed, remaining, nil
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditClientCmd)
+	auditClientCmd.Flags().Bool("fix", false, "Add 'use client' where client features are used without it")
+	auditClientCmd.Flags().Bool("fix-remove", false, "Remove 'use client' where no client features were detected")
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditClient(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	files := map[string]string{
+		"app/page.tsx":             "export default function Home() {\n  return <h1>Home</h1>;\n}",
+		"app/error.tsx":            "export default function Error() {\n  return <p>Oops</p>;\n}",
+		"app/counter/page.tsx":     "import { useState } from 'react';\n\nexport default function Counter() {\n  const [n, setN] = useState(0);\n  return <button onClick={() => setN(n + 1)}>{n}</button>;\n}",
+		"app/about/page.tsx":       "'use client';\n\nexport default function About() {\n  return <h1>About</h1>;\n}",
+		"app/posts/page.tsx":       "'use client';\n\nexport default async function Posts() {\n  const posts = await fetch('/api/posts');\n  return <ul />;\n}",
+		"app/feed/page.tsx":        "export default async function Feed() {\n  const [n] = useState(0);\n  return <p>{n}</p>;\n}",
+		"app/api/posts/route.ts":   "export async function GET() {\n  return Response.json(window.location);\n}",
+		"app/settings/page.tsx":    "'use client';\n\nimport { useSettings } from './settings';\n\nexport default function Settings() {\n  const settings = useSettings();\n  return <p>{settings.name}</p>;\n}",
+		"app/settings/settings.ts": "'use server';\n\nexport async function save() {}",
+		"app/providers.tsx":        "'use client';\n\nimport { createContext } from 'react';\n\nconst Theme = createContext('light');\n\nexport default function Providers({ children }) {\n  return <Theme.Provider value=\"dark\">{children}</Theme.Provider>;\n}",
+	}
+	for path, content := range files {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(path), []byte(content), 0644))
+	}
+
+	findings, err := auditClient(fs, "app")
+	assert.NoError(t, err)
+	fixes := make(map[string]string)
+	for _, finding := range findings {
+		fixes[filepath.ToSlash(finding.File)] = finding.Fix
+	}
+	assert.Equal(t, map[string]string{
+		"app/error.tsx":        fixAddClient,
+		"app/counter/page.tsx": fixAddClient,
+		"app/about/page.tsx":   fixRemoveClient,
+		"app/posts/page.tsx":   fixRemoveClient,
+		"app/feed/page.tsx":    "",
+	}, fixes)
+
+	// --fix only adds the directive, removing it is opt-in
+	fixed, remaining, err := applyClientFixes(fs, findings, true, false)
+	assert.NoError(t, err)
+	assert.Len(t, fixed, 2)
+	assert.Len(t, remaining, 3)
+	about, _ := afero.ReadFile(fs, filepath.Join("app", "about", "page.tsx"))
+	assert.Equal(t, files["app/about/page.tsx"], string(about))
+
+	counter, _ := afero.ReadFile(fs, filepath.Join("app", "counter", "page.tsx"))
+	assert.Equal(t, "'use client';\n\n"+files["app/counter/page.tsx"], string(counter))
+
+	fixed, remaining, err = applyClientFixes(fs, remaining, false, true)
+	assert.NoError(t, err)
+	assert.Len(t, fixed, 2)
+	assert.Len(t, remaining, 1)
+	about, _ = afero.ReadFile(fs, filepath.Join("app", "about", "page.tsx"))
+	assert.Equal(t, "export default function About() {\n  return <h1>About</h1>;\n}", string(about))
+
+	findings, err = auditClient(fs, "app")
+	assert.NoError(t, err)
+	assert.Len(t, findings, 1)
+	assert.Empty(t, findings[0].Fix)
+}
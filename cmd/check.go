@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/helpers"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Validates the routes in your Next.js project.",
+	Long: `Scans the app/pages directory and reports route problems Next.js would reject:
+- Invalid dynamic segments (e.g. '[slug', '[[id]]').
+- Catch-all segments that are not last.
+- Multiple files resolving to the same URL.
+- Sibling dynamic segments with different names (e.g. '[id]' and '[slug]').
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		stagedFlag, _ := cmd.Flags().GetBool("staged")
+
+		config, err := constants.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		// With --staged the routes are read from the index, so unstaged edits don't affect the result
+		fs := AppFs
+		if stagedFlag {
+			fs, err = indexFs(config.RoutesDir())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading the git index:\n%v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		found, err := routes.Scan(fs, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning routes:\n%v\n", err)
+			os.Exit(1)
+		}
+		issues := routes.Check(found)
+
+		if stagedFlag {
+			staged, err := helpers.GitStagedFiles()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error listing staged files:\n%v\n", err)
+				os.Exit(1)
+			}
+			issues = filterIssues(issues, staged, config.RoutesDir())
+		}
+
+		if len(issues) == 0 {
+			fmt.Println("No route issues found.")
+			return
+		}
+		for _, issue := range issues {
+			fmt.Fprintln(os.Stderr, issue)
+		}
+		fmt.Fprintf(os.Stderr, "%d route issue(s) found.\n", len(issues))
+		os.Exit(1)
+	},
+}
+
+// filterIssues keeps only the issues caused by one of the given files under the routes directory.
+func filterIssues(issues []routes.Issue, files []string, routesDir string) []routes.Issue {
+	keep := make(map[string]bool)
+	for _, file := range files {
+		file = filepath.Clean(file)
+		if strings.HasPrefix(file, routesDir+string(filepath.Separator)) {
+			keep[file] = true
+		}
+	}
+
+	var filtered []routes.Issue
+	for _, issue := range issues {
+		if keep[filepath.Clean(issue.File)] {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// indexFs returns an in-memory filesystem holding the staged content of the routes directory.
+func indexFs(routesDir string) (afero.Fs, error) {
+	files, err := helpers.GitIndexFiles(routesDir)
+	if err != nil {
+		return nil, err
+	}
+	fs := afero.NewMemMapFs()
+	for _, file := range files {
+		content, err := helpers.GitIndexContent(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read staged file '%s': %w", file, err)
+		}
+		if err := createPageFile(fs, file, string(content)); err != nil {
+			return nil, err
+		}
+	}
+	return fs, nil
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	checkCmd.Flags().Bool("staged", false, "Only report issues caused by files staged for commit")
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterIssuesDuplicateURL(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for _, file := range []string{"pages/about.tsx", "pages/about/index.tsx"} {
+		assert.NoError(t, afero.WriteFile(fs, file, []byte("export default function Page() {}"), 0644))
+	}
+	found, err := routes.Scan(fs, &constants.Config{Router: constants.PagesRouter})
+	assert.NoError(t, err)
+	issues := routes.Check(found)
+
+	// Staging either side of the conflict must keep it
+	for _, staged := range []string{"pages/about.tsx", filepath.Join("pages", "about", "index.tsx")} {
+		filtered := filterIssues(issues, []string{staged}, "pages")
+		assert.Len(t, filtered, 1, staged)
+		assert.Equal(t, staged, filtered[0].File)
+	}
+	assert.Empty(t, filterIssues(issues, []string{"README.md"}, "pages"))
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var clientCmd = &cobra.Command{
+	Use:   "client",
+	Short: "Marks existing pages as client or server components.",
+}
+
+var clientAddCmd = &cobra.Command{
+	Use:   "add [page-name] --flag",
+	Short: "Adds 'use client' to existing pages.",
+	Long: `Inserts the 'use client' directive at the top of existing pages, after any header comments.
+- Page name is the same path used with 'add' (e.g., 'blog/[slug]'), the route URL or a glob pattern (e.g., 'dashboard/**').
+- Use --special to update the page's special files (layout, loading, ...) as well.
+- Files exporting metadata, generateStaticParams or segment config, and async components, are refused unless --force is given.
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runClientToggle(cmd, args, true)
+	},
+}
+
+var clientRmCmd = &cobra.Command{
+	Use:   "rm [page-name] --flag",
+	Short: "Removes 'use client' from existing pages.",
+	Long: `Removes the 'use client' directive from existing pages, keeping header comments and other directives.
+- Page name is the same path used with 'add' (e.g., 'blog/[slug]'), the route URL or a glob pattern (e.g., 'dashboard/**').
+- Use --special to update the page's special files (layout, loading, ...) as well. Error boundaries always stay client components.
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		runClientToggle(cmd, args, false)
+	},
+}
+
+func runClientToggle(cmd *cobra.Command, args []string, useClient bool) {
+	specialFlag, _ := cmd.Flags().GetBool("special")
+	forceFlag, _ := cmd.Flags().GetBool("force")
+
+	config, err := constants.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+		fmt.Fprintln(os.Stderr, "Please run 'nextjs-routing-helper-cli init' first.")
+		os.Exit(1)
+	}
+	if config.Router != constants.AppRouter {
+		fmt.Fprintln(os.Stderr, "Client components are only supported by the app router.")
+		os.Exit(1)
+	}
+
+	found, err := routes.Scan(AppFs, config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error scanning routes:\n%v\n", err)
+		os.Exit(1)
+	}
+
+	for _, pageNameInput := range args {
+		matched := matchRoutes(found, config, pageNameInput)
+		if len(matched) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: no page found for '%s'\n", pageNameInput)
+			os.Exit(1)
+		}
+		for _, route := range matched {
+			changed, err := toggleClient(AppFs, route, specialFlag, useClient, forceFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error updating %s:\n%v\n", route.URL, err)
+				os.Exit(1)
+			}
+			for _, file := range changed {
+				fmt.Printf("Updated %s\n", file)
+			}
+		}
+	}
+}
+
+// matchRoutes returns the pages matching the input, which is either a route as accepted by
+// findRoute or a glob pattern over the route locations where '**' matches any number of segments.
+func matchRoutes(found []routes.Route, config *constants.Config, input string) []routes.Route {
+	var matched []routes.Route
+	if !strings.ContainsAny(input, "*?") {
+		if route := findRoute(found, config, input); route != nil && route.Kind == routes.PageKind {
+			matched = append(matched, *route)
+		}
+		return matched
+	}
+
+	pattern := strings.Split(strings.Trim(path.Clean("/"+strings.ReplaceAll(input, "\\", "/")), "/"), "/")
+	for _, route := range found {
+		if route.Kind != routes.PageKind {
+			continue
+		}
+		var segments []string
+		if location := route.Location(config); location != "" {
+			segments = strings.Split(location, "/")
+		}
+		if globMatch(pattern, segments) {
+			matched = append(matched, route)
+		}
+	}
+	return matched
+}
+
+// globMatch matches path segments against a pattern, where '**' matches zero or more segments.
+func globMatch(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if globMatch(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	// Escape brackets so dynamic segments like '[slug]' match literally
+	escaped := strings.NewReplacer("[", "\\[", "]", "\\]").Replace(pattern[0])
+	if ok, _ := path.Match(escaped, segments[0]); !ok {
+		return false
+	}
+	return globMatch(pattern[1:], segments[1:])
+}
+
+// toggleClient adds or removes 'use client' in the route's page and optionally its special files,
+// returning the files that changed. Files that can't be client components are refused unless forced.
+func toggleClient(fs afero.Fs, route routes.Route, withSpecial, useClient, force bool) ([]string, error) {
+	files := []string{route.File}
+	if withSpecial {
+		var special []string
+		for name, file := range route.Special {
+			// Error boundaries must stay client components
+			if !useClient && slices.Contains(clientOnlyFiles, name) {
+				continue
+			}
+			special = append(special, file)
+		}
+		sort.Strings(special)
+		files = append(files, special...)
+	}
+
+	contents := make([]string, len(files))
+	var refused []string
+	for i, file := range files {
+		content, err := afero.ReadFile(fs, file)
+		if err != nil {
+			return nil, err
+		}
+		contents[i] = string(content)
+		if useClient && !force && !routes.IsClientComponent(content) {
+			if reasons := serverOnlyReasons(content); len(reasons) > 0 {
+				refused = append(refused, fmt.Sprintf("%s %s", file, strings.Join(reasons, " and ")))
+			}
+		}
+	}
+	if len(refused) > 0 {
+		return nil, fmt.Errorf("client components cannot export metadata, generateStaticParams or segment config, or be async:\n- %s\nUse --force to add 'use client' anyway",
+			strings.Join(refused, "\n- "))
+	}
+
+	var changed []string
+	for i, file := range files {
+		updated := routes.RemoveDirective(contents[i], "use client")
+		if useClient {
+			updated = routes.AddDirective(contents[i], "use client")
+		}
+		if updated == contents[i] {
+			continue
+		}
+		if err := afero.WriteFile(fs, file, []byte(updated), 0644); err != nil {
+			return changed, err
+		}
+		changed = append(changed, file)
+	}
+	return changed, nil
+}
+
+// serverOnlyReasons explains why a file can't become a client component, if it can't.
+func serverOnlyReasons(content []byte) []string {
+	var reasons []string
+	if exports := routes.ServerOnlyExports(content); len(exports) > 0 {
+		reasons = append(reasons, "exports "+strings.Join(exports, ", "))
+	}
+	if routes.HasAsyncComponent(content) {
+		reasons = append(reasons, "is an async component")
+	}
+	return reasons
+}
+
+func init() {
+	rootCmd.AddCommand(clientCmd)
+	clientCmd.AddCommand(clientAddCmd)
+	clientCmd.AddCommand(clientRmCmd)
+	for _, cmd := range []*cobra.Command{clientAddCmd, clientRmCmd} {
+		cmd.Flags().Bool("special", false, "Also update the page's special files (layout, loading, ...)")
+	}
+	clientAddCmd.Flags().Bool("force", false, "Add 'use client' even to files that can't be client components")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchRoutes(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	config := &constants.Config{Router: constants.AppRouter}
+	for _, file := range []string{
+		"app/page.tsx",
+		"app/dashboard/page.tsx",
+		"app/dashboard/settings/page.tsx",
+		"app/dashboard/[team]/page.tsx",
+		"app/dashboard/api/route.ts",
+		"app/blog/page.tsx",
+	} {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(file), []byte(""), 0644))
+	}
+	found, err := routes.Scan(fs, config)
+	assert.NoError(t, err)
+
+	urls := func(matched []routes.Route) []string {
+		var result []string
+		for _, route := range matched {
+			result = append(result, route.URL)
+		}
+		return result
+	}
+	assert.Equal(t, []string{"/dashboard", "/dashboard/[team]", "/dashboard/settings"}, urls(matchRoutes(found, config, "dashboard/**")))
+	assert.Equal(t, []string{"/dashboard/[team]", "/dashboard/settings"}, urls(matchRoutes(found, config, "dashboard/*")))
+	assert.Equal(t, []string{"/dashboard/[team]"}, urls(matchRoutes(found, config, "dashboard/[team]")))
+	assert.Equal(t, []string{"/blog"}, urls(matchRoutes(found, config, "/blog")))
+	assert.Len(t, matchRoutes(found, config, "**"), 5)
+	assert.Empty(t, matchRoutes(found, config, "dashboard/api"))
+}
+
+func TestToggleClient(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	page := "// Dashboard page\n'use strict';\nexport default function Dashboard() {}"
+	files := map[string]string{
+		"app/dashboard/page.tsx":    page,
+		"app/dashboard/layout.tsx":  "export default function DashboardLayout() {}",
+		"app/dashboard/error.tsx":   "'use client';\n\nexport default function DashboardError() {}",
+		"app/dashboard/loading.tsx": "'use client';\n\nexport default function DashboardLoading() {}",
+	}
+	for path, content := range files {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(path), []byte(content), 0644))
+	}
+	found, err := routes.Scan(fs, &constants.Config{Router: constants.AppRouter})
+	assert.NoError(t, err)
+	route := found[0]
+
+	changed, err := toggleClient(fs, route, false, true, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("app", "dashboard", "page.tsx")}, changed)
+	content, _ := afero.ReadFile(fs, route.File)
+	assert.Equal(t, "// Dashboard page\n'use client';\n'use strict';\nexport default function Dashboard() {}", string(content))
+
+	changed, err = toggleClient(fs, route, true, false, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join("app", "dashboard", "page.tsx"),
+		filepath.Join("app", "dashboard", "loading.tsx"),
+	}, changed)
+	content, _ = afero.ReadFile(fs, route.File)
+	assert.Equal(t, page, string(content))
+	content, _ = afero.ReadFile(fs, filepath.Join("app", "dashboard", "error.tsx"))
+	assert.Equal(t, files["app/dashboard/error.tsx"], string(content))
+
+	// Server-only exports and async components are refused unless forced, without touching any file
+	layout := "export const metadata = { title: 'Dashboard' };\nexport const revalidate = 60;\nexport default async function DashboardLayout() {}"
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join("app", "dashboard", "layout.tsx"), []byte(layout), 0644))
+	_, err = toggleClient(fs, route, true, true, false)
+	assert.ErrorContains(t, err, filepath.Join("app", "dashboard", "layout.tsx")+" exports metadata, revalidate and is an async component")
+	content, _ = afero.ReadFile(fs, route.File)
+	assert.Equal(t, page, string(content))
+
+	changed, err = toggleClient(fs, route, true, true, true)
+	assert.NoError(t, err)
+	assert.Contains(t, changed, filepath.Join("app", "dashboard", "layout.tsx"))
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/afero"
 )
@@ -16,6 +17,49 @@ type Config struct {
 	ComponentStyle      ComponentStyleType `json:"componentStyle"`
 	SrcFolder           bool               `json:"srcFolder"`
 	PageComponentSuffix string             `json:"pageComponentSuffix"`
+	// Styling is how generated pages are styled: css-modules, sass, tailwind, styled-components or none
+	Styling StylingType `json:"styling,omitempty"`
+	// ComponentsDir is where 'add-component' puts shared components, "components" by default
+	ComponentsDir string `json:"componentsDir,omitempty"`
+	// PacksDir is where 'add --pack' looks up template packs, ".nextjs_routing_helper/packs" by default
+	PacksDir string `json:"packsDir,omitempty"`
+	// NextVersion overrides the Next.js version read from package.json (e.g. "14.2.0")
+	NextVersion string `json:"nextVersion,omitempty"`
+	// Testing configures the tests generated with 'add --with-tests'
+	Testing TestingConfig `json:"testing,omitzero"`
+	// SegmentConfig holds the default segment config exports of new app router pages
+	SegmentConfig SegmentConfig `json:"segmentConfig,omitzero"`
+}
+
+// RoutesDir returns the directory holding the routes for the configured router (e.g. "src/app").
+func (c *Config) RoutesDir() string {
+	dir := "pages"
+	if c.Router == AppRouter {
+		dir = "app"
+	}
+	if c.SrcFolder {
+		return filepath.Join("src", dir)
+	}
+	return dir
+}
+
+// ComponentsRoot returns the directory holding shared components (e.g. "src/components").
+func (c *Config) ComponentsRoot() string {
+	if c.ComponentsDir != "" {
+		return filepath.Clean(c.ComponentsDir)
+	}
+	if c.SrcFolder {
+		return filepath.Join("src", "components")
+	}
+	return "components"
+}
+
+// PacksRoot returns the directory holding the template packs.
+func (c *Config) PacksRoot() string {
+	if c.PacksDir != "" {
+		return filepath.Clean(c.PacksDir)
+	}
+	return filepath.Join(".nextjs_routing_helper", "packs")
 }
 
 // loadConfig reads and parses the config file
@@ -30,6 +74,12 @@ func LoadConfig() (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("could not parse config file '%s': %w", ConfigFileName, err)
 	}
+	if err := config.SegmentConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid segmentConfig in config file '%s': %w", ConfigFileName, err)
+	}
+	if err := config.Testing.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid testing in config file '%s': %w", ConfigFileName, err)
+	}
 	return &config, nil
 }
 
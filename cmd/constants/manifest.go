@@ -0,0 +1,82 @@
+package constants
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+const ManifestFileName = "routes.yaml"
+
+// Manifest describes the routes a project should have.
+type Manifest struct {
+	Routes []ManifestRoute `yaml:"routes" json:"routes"`
+}
+
+// ManifestRoute is a single route entry in the manifest.
+type ManifestRoute struct {
+	// Path is the page name as passed to 'add' (e.g. "blog/[slug]"), "/" for the root page.
+	Path     string   `yaml:"path" json:"path"`
+	Kind     string   `yaml:"kind,omitempty" json:"kind,omitempty"`
+	Client   bool     `yaml:"client,omitempty" json:"client,omitempty"`
+	Special  []string `yaml:"special,omitempty" json:"special,omitempty"`
+	Template string   `yaml:"template,omitempty" json:"template,omitempty"`
+	// Style overrides the configured component style for this route.
+	Style ComponentStyleType `yaml:"style,omitempty" json:"style,omitempty"`
+	// File and Params are informational, written by 'export manifest'.
+	File   string   `yaml:"file,omitempty" json:"file,omitempty"`
+	Params []string `yaml:"params,omitempty" json:"params,omitempty"`
+}
+
+// LoadManifest reads and parses a YAML or JSON manifest file.
+func LoadManifest(fs afero.Fs, path string) (*Manifest, error) {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read manifest file '%s': %w", path, err)
+	}
+
+	var manifest Manifest
+	// YAML is a superset of JSON, so both formats go through the same decoder
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("could not parse manifest file '%s': %w", path, err)
+	}
+	for i, route := range manifest.Routes {
+		switch route.Kind {
+		case "":
+			manifest.Routes[i].Kind = "page"
+		case "page", "api":
+		default:
+			return nil, fmt.Errorf("invalid kind '%s' for route '%s', expected 'page' or 'api'", route.Kind, route.Path)
+		}
+		switch route.Style {
+		case "", Function, Const:
+		default:
+			return nil, fmt.Errorf("invalid component style '%s' for route '%s', expected '%s' or '%s'", route.Style, route.Path, Function, Const)
+		}
+	}
+	return &manifest, nil
+}
+
+// WriteManifest writes the manifest as JSON when the path ends in ".json", YAML otherwise.
+func WriteManifest(fs afero.Fs, path string, manifest Manifest) error {
+	var data []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		data, err = json.MarshalIndent(manifest, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(manifest)
+	}
+	if err != nil {
+		return fmt.Errorf("error marshalling manifest: %w", err)
+	}
+
+	if err := afero.WriteFile(fs, path, data, 0644); err != nil {
+		return fmt.Errorf("error writing manifest file '%s': %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package constants
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+const PackManifestFileName = "pack.json"
+
+// Variable types of a template pack
+var PackVariableTypes = []string{"string", "bool", "number"}
+
+var packVariableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Pack is a directory of templates described by its pack.json manifest.
+type Pack struct {
+	Name        string         `json:"name,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Variables   []PackVariable `json:"variables,omitempty"`
+	Files       []PackFile     `json:"files"`
+}
+
+// PackVariable is an extra value the user is asked for, available to templates as .Vars.<name>.
+type PackVariable struct {
+	Name string `json:"name"`
+	// Type is string, bool or number, string by default
+	Type   string `json:"type,omitempty"`
+	Prompt string `json:"prompt,omitempty"`
+	// Default is used when no value is given; variables without one are required
+	Default any      `json:"default,omitempty"`
+	Choices []string `json:"choices,omitempty"`
+}
+
+// PackFile is a template of the pack and where it is rendered to.
+type PackFile struct {
+	// Template is the template file, relative to the pack directory
+	Template string `json:"template"`
+	// Path is the output path relative to the route directory, itself a template (e.g. "_components/{{ pascal .Vars.entity }}Form.tsx")
+	Path string `json:"path"`
+	// If is a template condition (e.g. ".Vars.withForm" or "eq .Router \"app\""); the file is skipped when it is false
+	If string `json:"if,omitempty"`
+}
+
+// LoadPack reads and validates the pack manifest in the directory.
+func LoadPack(fs afero.Fs, dir string) (*Pack, error) {
+	path := filepath.Join(dir, PackManifestFileName)
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read pack manifest '%s': %w", path, err)
+	}
+
+	var pack Pack
+	if err := json.Unmarshal(data, &pack); err != nil {
+		return nil, fmt.Errorf("could not parse pack manifest '%s': %w", path, err)
+	}
+	if err := pack.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid pack manifest '%s': %w", path, err)
+	}
+	return &pack, nil
+}
+
+// CleanPackPath cleans the path of a pack file, which must stay inside the route directory.
+func CleanPackPath(path string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(path))
+	if filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path '%s' must stay inside the route directory", path)
+	}
+	return clean, nil
+}
+
+// Validate checks the variables and files of the pack.
+func (p *Pack) Validate() error {
+	if len(p.Files) == 0 {
+		return fmt.Errorf("no files declared")
+	}
+	for _, file := range p.Files {
+		if file.Template == "" || file.Path == "" {
+			return fmt.Errorf("files need both a 'template' and a 'path'")
+		}
+		if _, err := CleanPackPath(file.Path); err != nil {
+			return err
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i := range p.Variables {
+		v := &p.Variables[i]
+		if !packVariableName.MatchString(v.Name) {
+			return fmt.Errorf("invalid variable name '%s'", v.Name)
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("duplicate variable '%s'", v.Name)
+		}
+		seen[v.Name] = true
+		if v.Type == "" {
+			v.Type = "string"
+		}
+		if !slices.Contains(PackVariableTypes, v.Type) {
+			return fmt.Errorf("invalid type '%s' for variable '%s', expected one of: %s", v.Type, v.Name, strings.Join(PackVariableTypes, ", "))
+		}
+		if len(v.Choices) > 0 && v.Type != "string" {
+			return fmt.Errorf("choices of variable '%s' are only supported for strings", v.Name)
+		}
+		if v.Default != nil {
+			value, err := v.Parse(fmt.Sprint(v.Default))
+			if err != nil {
+				return fmt.Errorf("invalid default: %w", err)
+			}
+			v.Default = value
+		}
+	}
+	return nil
+}
+
+// Parse converts a raw value to the variable's type, checking it against the choices.
+func (v PackVariable) Parse(raw string) (any, error) {
+	switch v.Type {
+	case "bool":
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("variable '%s' expects true or false, got '%s'", v.Name, raw)
+		}
+		return value, nil
+	case "number":
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("variable '%s' expects a number, got '%s'", v.Name, raw)
+		}
+		return value, nil
+	}
+	if len(v.Choices) > 0 && !slices.Contains(v.Choices, raw) {
+		return nil, fmt.Errorf("invalid value '%s' for variable '%s', expected one of: %s", raw, v.Name, strings.Join(v.Choices, ", "))
+	}
+	return raw, nil
+}
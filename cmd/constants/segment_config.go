@@ -0,0 +1,49 @@
+package constants
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Allowed values of the app router segment config exports
+var (
+	DynamicValues    = []string{"auto", "force-dynamic", "error", "force-static"}
+	RuntimeValues    = []string{"nodejs", "edge"}
+	FetchCacheValues = []string{"auto", "default-cache", "only-cache", "force-cache", "force-no-store", "default-no-store", "only-no-store"}
+)
+
+// SegmentConfig holds the route segment config exports of generated app router pages.
+// Empty fields are not exported.
+type SegmentConfig struct {
+	Dynamic    string `json:"dynamic,omitempty"`
+	Revalidate *int   `json:"revalidate,omitempty"`
+	Runtime    string `json:"runtime,omitempty"`
+	FetchCache string `json:"fetchCache,omitempty"`
+}
+
+// Validate checks the values against the ones Next.js accepts.
+func (s SegmentConfig) Validate() error {
+	for _, option := range []struct {
+		name    string
+		value   string
+		allowed []string
+	}{
+		{"dynamic", s.Dynamic, DynamicValues},
+		{"runtime", s.Runtime, RuntimeValues},
+		{"fetchCache", s.FetchCache, FetchCacheValues},
+	} {
+		if option.value != "" && !slices.Contains(option.allowed, option.value) {
+			return fmt.Errorf("invalid %s value '%s', expected one of: %s", option.name, option.value, strings.Join(option.allowed, ", "))
+		}
+	}
+	if s.Revalidate != nil && *s.Revalidate < 0 {
+		return fmt.Errorf("invalid revalidate value %d, expected a number of seconds", *s.Revalidate)
+	}
+	return nil
+}
+
+// IsZero reports whether no segment config export is set.
+func (s SegmentConfig) IsZero() bool {
+	return s.Dynamic == "" && s.Revalidate == nil && s.Runtime == "" && s.FetchCache == ""
+}
@@ -0,0 +1,50 @@
+package constants
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type StylingType string
+
+const (
+	NoStyling        StylingType = ""
+	CSSModules       StylingType = "css-modules"
+	Sass             StylingType = "sass"
+	Tailwind         StylingType = "tailwind"
+	StyledComponents StylingType = "styled-components"
+)
+
+func (st StylingType) String() string {
+	return string(st)
+}
+
+// StylesheetExtension returns the extension of the stylesheet generated next to pages, or "" when there is none.
+func (st StylingType) StylesheetExtension() string {
+	switch st {
+	case CSSModules:
+		return ".module.css"
+	case Sass:
+		return ".module.scss"
+	}
+	return ""
+}
+
+func (st *StylingType) UnmarshalJSON(data []byte) error {
+	var s string
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("styling should be a string, got %s: %w", data, err)
+	}
+
+	value := StylingType(strings.ToLower(s))
+
+	switch value {
+	case NoStyling, CSSModules, Sass, Tailwind, StyledComponents:
+		*st = value
+		return nil
+	default:
+		return fmt.Errorf("invalid styling value '%s', expected '%s', '%s', '%s' or '%s'", s, CSSModules, Sass, Tailwind, StyledComponents)
+	}
+}
@@ -0,0 +1,53 @@
+package constants
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Unit test frameworks and locations of the testing config
+var (
+	TestFrameworks = []string{"jest", "vitest"}
+	TestLocations  = []string{"colocated", "__tests__"}
+)
+
+// TestingConfig describes where and how tests are generated along with pages.
+// Empty fields fall back to jest, colocated tests and an "e2e" directory.
+type TestingConfig struct {
+	Framework string `json:"framework,omitempty"`
+	Location  string `json:"location,omitempty"`
+	E2EDir    string `json:"e2eDir,omitempty"`
+}
+
+// Validate checks the framework and location values.
+func (t TestingConfig) Validate() error {
+	if t.Framework != "" && !slices.Contains(TestFrameworks, t.Framework) {
+		return fmt.Errorf("invalid framework '%s', expected one of: %s", t.Framework, strings.Join(TestFrameworks, ", "))
+	}
+	if t.Location != "" && !slices.Contains(TestLocations, t.Location) {
+		return fmt.Errorf("invalid location '%s', expected one of: %s", t.Location, strings.Join(TestLocations, ", "))
+	}
+	return nil
+}
+
+// TestFramework returns the unit test framework, "jest" by default.
+func (t TestingConfig) TestFramework() string {
+	if t.Framework == "" {
+		return "jest"
+	}
+	return t.Framework
+}
+
+// Colocated reports whether unit tests live next to the files they test.
+func (t TestingConfig) Colocated() bool {
+	return t.Location == "" || t.Location == "colocated"
+}
+
+// E2EDirectory returns the directory of the Playwright specs, "e2e" by default.
+func (t TestingConfig) E2EDirectory() string {
+	if t.E2EDir == "" {
+		return "e2e"
+	}
+	return t.E2EDir
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports information about your Next.js project.",
+}
+
+var exportManifestCmd = &cobra.Command{
+	Use:   "manifest --flag",
+	Short: "Writes a route manifest describing the existing routes.",
+	Long: fmt.Sprintf(`Scans the app/pages directory and writes a route manifest (%s by default).
+Each route records its path, file, dynamic params, special files, 'use client' status
+and detected component style. Use a '.json' output file to write JSON instead of YAML.
+
+The manifest round-trips: running 'sync' with it in an empty project recreates the same routes.
+`, constants.ManifestFileName),
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		outFlag, _ := cmd.Flags().GetString("out")
+
+		config, err := constants.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+			os.Exit(1)
+		}
+		found, err := routes.Scan(AppFs, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning routes:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		manifest, err := buildManifest(AppFs, config, found)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error building manifest:\n%v\n", err)
+			os.Exit(1)
+		}
+		if err := constants.WriteManifest(AppFs, outFlag, *manifest); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing manifest:\n%v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Manifest with %d route(s) written to %s\n", len(manifest.Routes), outFlag)
+	},
+}
+
+// manifestPath returns the 'add' style path of a route, keeping route groups so 'sync' recreates the same layout.
+func manifestPath(config *constants.Config, route routes.Route) string {
+	if location := route.Location(config); location != "" {
+		return location
+	}
+	return "/"
+}
+
+// buildManifest describes the scanned routes as a manifest.
+func buildManifest(fs afero.Fs, config *constants.Config, found []routes.Route) (*constants.Manifest, error) {
+	manifest := &constants.Manifest{Routes: []constants.ManifestRoute{}}
+	for _, route := range found {
+		entry := constants.ManifestRoute{
+			Path:   manifestPath(config, route),
+			Kind:   string(route.Kind),
+			File:   filepath.ToSlash(route.File),
+			Params: route.Params,
+		}
+
+		if route.Kind == routes.PageKind {
+			content, err := afero.ReadFile(fs, route.File)
+			if err != nil {
+				return nil, fmt.Errorf("could not read file '%s': %w", route.File, err)
+			}
+			entry.Client = routes.IsClientComponent(content)
+			if style := routes.DetectComponentStyle(content); style != config.ComponentStyle {
+				entry.Style = style
+			}
+		}
+
+		for name := range route.Special {
+			entry.Special = append(entry.Special, name)
+		}
+		sort.Strings(entry.Special)
+
+		manifest.Routes = append(manifest.Routes, entry)
+	}
+	return manifest, nil
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportManifestCmd)
+	exportManifestCmd.Flags().String("out", constants.ManifestFileName, "Output file ('.json' for JSON, YAML otherwise)")
+}
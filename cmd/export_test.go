@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportManifestRoundTrip(t *testing.T) {
+	fs := useTemplateFs(t)
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: constants.Function}
+	files := map[string]string{
+		"app/page.tsx":                    "export default function Home() {}",
+		"app/layout.tsx":                  "",
+		"app/(shop)/cart/page.tsx":        "'use client';\n\nconst Cart = () => {\n  return null;\n};\n\nexport default Cart;",
+		"app/blog/[slug]/page.tsx":        "export default async function Post() {}",
+		"app/blog/[slug]/loading.tsx":     "",
+		"app/api/users/[id]/route.ts":     "",
+		"app/blog/_components/Header.tsx": "",
+	}
+	for file, content := range files {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(file), []byte(content), 0644))
+	}
+
+	found, err := routes.Scan(fs, config)
+	assert.NoError(t, err)
+	manifest, err := buildManifest(fs, config, found)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.ManifestRoute{
+		Path: "(shop)/cart", Kind: "page", Client: true, Style: constants.Const, File: "app/(shop)/cart/page.tsx",
+	}, manifest.Routes[3])
+
+	// Write, read back and scaffold into an empty project
+	assert.NoError(t, constants.WriteManifest(fs, "routes.yaml", *manifest))
+	loaded, err := constants.LoadManifest(fs, "routes.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, manifest, loaded)
+
+	empty := useTemplateFs(t)
+	plan, err := planSync(empty, config, loaded, nil)
+	assert.NoError(t, err)
+	for _, file := range plan.Create {
+		assert.NoError(t, createPageFile(empty, file.Path, file.Content))
+	}
+
+	recreated, err := routes.Scan(empty, config)
+	assert.NoError(t, err)
+	roundTrip, err := buildManifest(empty, config, recreated)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest, roundTrip)
+}
@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/helpers"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+const generatedHeader = "// This file is generated by nextjs-routing-helper. Do not edit it by hand."
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+var genCmd = &cobra.Command{
+	Use:   "gen",
+	Short: "Generates code from the routes in your Next.js project.",
+}
+
+var genTypesCmd = &cobra.Command{
+	Use:   "types --flag",
+	Short: "Generates a typed routes.ts module from your routes.",
+	Long: `Walks the route tree and writes a TypeScript module containing:
+- A union type of every route pattern and of every valid pathname.
+- The param types of each route.
+- Typed builders returning URLs, e.g. 'routes.blog.slug({ slug })'.
+The output is deterministic, so 'gen types --check' can fail CI when it is stale.
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkFlag, _ := cmd.Flags().GetBool("check")
+		outFlag, _ := cmd.Flags().GetString("out")
+
+		config, err := constants.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+			os.Exit(1)
+		}
+		if outFlag == "" {
+			outFlag = "routes.ts"
+			if config.SrcFolder {
+				outFlag = filepath.Join("src", "routes.ts")
+			}
+		}
+
+		found, err := routes.Scan(AppFs, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning routes:\n%v\n", err)
+			os.Exit(1)
+		}
+		content, err := generateRouteTypes(found)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating route types:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		if checkFlag {
+			existing, _ := afero.ReadFile(AppFs, outFlag)
+			if string(existing) != content {
+				fmt.Fprintf(os.Stderr, "%s is out of date. Run 'nextjs-routing-helper gen types' to regenerate it.\n", outFlag)
+				os.Exit(1)
+			}
+			fmt.Printf("%s is up to date.\n", outFlag)
+			return
+		}
+
+		if err := createPageFile(AppFs, outFlag, content); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing route types:\n%v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Route types written to %s\n", outFlag)
+	},
+}
+
+// routeNode is a segment in the builder tree of the generated module.
+type routeNode struct {
+	// path is the URL prefix up to this segment, reported when two segments share a key
+	path     string
+	route    *routes.Route
+	children map[string]*routeNode
+}
+
+// generateRouteTypes renders the routes.ts module for the given pages.
+func generateRouteTypes(found []routes.Route) (string, error) {
+	var pages []routes.Route
+	for _, route := range found {
+		if route.Kind == routes.PageKind {
+			pages = append(pages, route)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(generatedHeader + "\n\n")
+
+	if len(pages) == 0 {
+		b.WriteString("export type RoutePattern = never;\n\nexport type Pathname = never;\n\nexport type RouteParams = {};\n\nexport const routes = {} as const;\n")
+		return b.String(), nil
+	}
+
+	// Route patterns
+	b.WriteString("export type RoutePattern =\n")
+	for i, route := range pages {
+		b.WriteString(fmt.Sprintf("  | '%s'%s\n", route.URL, terminator(i, len(pages))))
+	}
+
+	// Pathnames, with dynamic segments as template literal types
+	var pathnames []string
+	seen := make(map[string]bool)
+	for _, route := range pages {
+		for _, pathname := range pathnameTypes(route.URL) {
+			if !seen[pathname] {
+				seen[pathname] = true
+				pathnames = append(pathnames, pathname)
+			}
+		}
+	}
+	b.WriteString("\nexport type Pathname =\n")
+	for i, pathname := range pathnames {
+		b.WriteString(fmt.Sprintf("  | %s%s\n", pathname, terminator(i, len(pathnames))))
+	}
+
+	// Params
+	b.WriteString("\nexport type RouteParams = {\n")
+	for _, route := range pages {
+		b.WriteString(fmt.Sprintf("  '%s': %s;\n", route.URL, paramsType(route.URL)))
+	}
+	b.WriteString("};\n\nexport type ParamsOf<R extends RoutePattern> = RouteParams[R];\n\n")
+
+	// Builders
+	root := &routeNode{children: make(map[string]*routeNode)}
+	for i := range pages {
+		node := root
+		path := ""
+		for _, raw := range routes.SplitURL(pages[i].URL) {
+			path += "/" + raw
+			key := builderKey(raw)
+			child := node.children[key]
+			if child == nil {
+				child = &routeNode{path: path, children: make(map[string]*routeNode)}
+				node.children[key] = child
+			} else if child.path != path {
+				return "", fmt.Errorf("%s and %s both map to the builder key '%s', rename one of them", child.path, path, key)
+			}
+			node = child
+		}
+		node.route = &pages[i]
+	}
+	b.WriteString("export const routes = " + renderRouteNode(root, 0) + ";\n")
+	return b.String(), nil
+}
+
+func terminator(i, n int) string {
+	if i == n-1 {
+		return ";"
+	}
+	return ""
+}
+
+// pathnameTypes returns the TypeScript types matching the pathnames a route pattern serves.
+func pathnameTypes(url string) []string {
+	parts := routes.SplitURL(url)
+	if len(parts) == 0 {
+		return []string{"'/'"}
+	}
+
+	var literal strings.Builder
+	dynamic := false
+	for i, raw := range parts {
+		seg, _ := routes.ParseSegment(raw)
+		switch {
+		case seg.Optional:
+			base := literal.String()
+			if base == "" {
+				base = "/"
+			}
+			return []string{quotePathname(base, dynamic), "`" + literal.String() + "/${string}`"}
+		case seg.Dynamic:
+			dynamic = true
+			literal.WriteString("/${string}")
+		default:
+			literal.WriteString("/" + parts[i])
+		}
+	}
+	return []string{quotePathname(literal.String(), dynamic)}
+}
+
+func quotePathname(pathname string, dynamic bool) string {
+	if dynamic {
+		return "`" + pathname + "`"
+	}
+	return "'" + pathname + "'"
+}
+
+// paramsType renders the params object type of a route pattern.
+func paramsType(url string) string {
+	var fields []string
+	for _, raw := range routes.SplitURL(url) {
+		seg, _ := routes.ParseSegment(raw)
+		switch {
+		case seg.Optional:
+			fields = append(fields, propertyName(seg.Name)+"?: string[]")
+		case seg.CatchAll:
+			fields = append(fields, propertyName(seg.Name)+": string[]")
+		case seg.Dynamic:
+			fields = append(fields, propertyName(seg.Name)+": string")
+		}
+	}
+	if len(fields) == 0 {
+		return "Record<string, never>"
+	}
+	return "{ " + strings.Join(fields, "; ") + " }"
+}
+
+// builderKey returns the property name of a segment in the routes object.
+func builderKey(raw string) string {
+	if seg, err := routes.ParseSegment(raw); err == nil && seg.Dynamic {
+		return helpers.ToCamelCase(seg.Name)
+	}
+	return helpers.ToCamelCase(raw)
+}
+
+// propertyName quotes names that are not valid identifiers, e.g. 'post-id'.
+func propertyName(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+	return "'" + name + "'"
+}
+
+// paramAccess returns the expression reading a param, e.g. "params.slug" or "params['post-id']".
+func paramAccess(name string) string {
+	if identifierPattern.MatchString(name) {
+		return "params." + name
+	}
+	return "params['" + name + "']"
+}
+
+// builderFunc renders the arrow function returning the URL of a route.
+func builderFunc(url string) string {
+	parts := routes.SplitURL(url)
+	if len(parts) == 0 {
+		return "() => '/' as const"
+	}
+
+	signature := fmt.Sprintf("(params: RouteParams['%s']): Pathname => ", url)
+	var path strings.Builder
+	dynamic := false
+	for _, raw := range parts {
+		seg, _ := routes.ParseSegment(raw)
+		param := paramAccess(seg.Name)
+		switch {
+		case seg.Optional:
+			// Both branches are literals of the Pathname union: '/docs' and `/docs/${string}`
+			base := path.String()
+			if base == "" {
+				base = "/"
+			}
+			return fmt.Sprintf("%s%s?.length ? `%s/${%s.map(encodeURIComponent).join('/')}` : %s",
+				signature, param, path.String(), param, quotePathname(base, dynamic))
+		case seg.CatchAll:
+			dynamic = true
+			path.WriteString(fmt.Sprintf("/${%s.map(encodeURIComponent).join('/')}", param))
+		case seg.Dynamic:
+			dynamic = true
+			path.WriteString(fmt.Sprintf("/${encodeURIComponent(%s)}", param))
+		default:
+			path.WriteString("/" + raw)
+		}
+	}
+	if !dynamic {
+		return fmt.Sprintf("() => '%s' as const", path.String())
+	}
+	return signature + "`" + path.String() + "`"
+}
+
+// renderRouteNode renders a builder tree node. Nodes that are pages and have children become
+// callable objects, so both 'routes.blog()' and 'routes.blog.slug({ slug })' work.
+func renderRouteNode(node *routeNode, depth int) string {
+	if len(node.children) == 0 {
+		if node.route == nil {
+			return "{}"
+		}
+		return builderFunc(node.route.URL)
+	}
+
+	keys := make([]string, 0, len(node.children))
+	for key := range node.children {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	indent := strings.Repeat("  ", depth+1)
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, key := range keys {
+		b.WriteString(fmt.Sprintf("%s%s: %s,\n", indent, propertyName(key), renderRouteNode(node.children[key], depth+1)))
+	}
+	b.WriteString(strings.Repeat("  ", depth) + "}")
+
+	if node.route == nil {
+		return b.String()
+	}
+	return fmt.Sprintf("Object.assign(%s, %s)", builderFunc(node.route.URL), b.String())
+}
+
+func init() {
+	rootCmd.AddCommand(genCmd)
+	genCmd.AddCommand(genTypesCmd)
+	genTypesCmd.Flags().Bool("check", false, "Exit with a non-zero status when the generated file is stale instead of writing it")
+	genTypesCmd.Flags().String("out", "", "Output file (defaults to 'routes.ts', or 'src/routes.ts' with a src folder)")
+}
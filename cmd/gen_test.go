@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateRouteTypes(t *testing.T) {
+	found := []routes.Route{
+		{URL: "/", Kind: routes.PageKind},
+		{URL: "/api/users", Kind: routes.APIKind},
+		{URL: "/blog", Kind: routes.PageKind},
+		{URL: "/blog/[slug]", Kind: routes.PageKind},
+		{URL: "/docs/[[...rest]]", Kind: routes.PageKind},
+		{URL: "/posts/[post-id]", Kind: routes.PageKind},
+		{URL: "/user-settings", Kind: routes.PageKind},
+	}
+
+	expected := generatedHeader + "\n\n" + `export type RoutePattern =
+  | '/'
+  | '/blog'
+  | '/blog/[slug]'
+  | '/docs/[[...rest]]'
+  | '/posts/[post-id]'
+  | '/user-settings';
+
+export type Pathname =
+  | '/'
+  | '/blog'
+  | ` + "`/blog/${string}`" + `
+  | '/docs'
+  | ` + "`/docs/${string}`" + `
+  | ` + "`/posts/${string}`" + `
+  | '/user-settings';
+
+export type RouteParams = {
+  '/': Record<string, never>;
+  '/blog': Record<string, never>;
+  '/blog/[slug]': { slug: string };
+  '/docs/[[...rest]]': { rest?: string[] };
+  '/posts/[post-id]': { 'post-id': string };
+  '/user-settings': Record<string, never>;
+};
+
+export type ParamsOf<R extends RoutePattern> = RouteParams[R];
+
+export const routes = Object.assign(() => '/' as const, {
+  blog: Object.assign(() => '/blog' as const, {
+    slug: (params: RouteParams['/blog/[slug]']): Pathname => ` + "`/blog/${encodeURIComponent(params.slug)}`" + `,
+  }),
+  docs: {
+    rest: (params: RouteParams['/docs/[[...rest]]']): Pathname => params.rest?.length ? ` + "`/docs/${params.rest.map(encodeURIComponent).join('/')}`" + ` : '/docs',
+  },
+  posts: {
+    postId: (params: RouteParams['/posts/[post-id]']): Pathname => ` + "`/posts/${encodeURIComponent(params['post-id'])}`" + `,
+  },
+  userSettings: () => '/user-settings' as const,
+});
+`
+	actual, err := generateRouteTypes(found)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// Output must be stable for 'gen types --check'
+	again, _ := generateRouteTypes(found)
+	assert.Equal(t, actual, again)
+
+	// A root optional catch-all falls back to '/'
+	actual, err = generateRouteTypes([]routes.Route{{URL: "/[[...slug]]", Kind: routes.PageKind}})
+	assert.NoError(t, err)
+	assert.Contains(t, actual, "(params: RouteParams['/[[...slug]]']): Pathname => params.slug?.length ? `/${params.slug.map(encodeURIComponent).join('/')}` : '/',")
+
+	// Segments sharing a builder key would overwrite each other
+	_, err = generateRouteTypes([]routes.Route{
+		{URL: "/user-settings", Kind: routes.PageKind},
+		{URL: "/user_settings", Kind: routes.PageKind},
+	})
+	assert.ErrorContains(t, err, "/user-settings and /user_settings both map to the builder key 'userSettings'")
+}
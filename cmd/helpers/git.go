@@ -0,0 +1,69 @@
+package helpers
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// git runs a git command in the current directory and returns its trimmed output.
+func git(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GitStagedFiles returns the added, copied, modified and renamed files in the index under the current directory,
+// relative to it, so they match the paths of a project living in a monorepo subdirectory.
+func GitStagedFiles() ([]string, error) {
+	out, err := git("diff", "--cached", "--relative", "--name-only", "--diff-filter=ACMR")
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		files = append(files, filepath.FromSlash(line))
+	}
+	return files, nil
+}
+
+// GitIndexFiles returns the files in the index under the directory, relative to the current directory.
+func GitIndexFiles(dir string) ([]string, error) {
+	out, err := git("ls-files", "--cached", "--", dir)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	var files []string
+	for _, line := range strings.Split(out, "\n") {
+		files = append(files, filepath.FromSlash(line))
+	}
+	return files, nil
+}
+
+// GitIndexContent returns the staged content of the file, which may differ from the working tree.
+func GitIndexContent(path string) ([]byte, error) {
+	// The output is returned as is, unlike git() which trims it
+	return exec.Command("git", "show", ":./"+filepath.ToSlash(path)).Output()
+}
+
+// GitHooksDir returns the directory git reads hooks from, honouring core.hooksPath.
+func GitHooksDir() (string, error) {
+	return git("rev-parse", "--git-path", "hooks")
+}
+
+// GitUser returns the configured git user name, or "" when it is not set.
+func GitUser() string {
+	name, err := git("config", "user.name")
+	if err != nil {
+		return ""
+	}
+	return name
+}
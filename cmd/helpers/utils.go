@@ -2,6 +2,7 @@ package helpers
 
 import (
 	"strings"
+	"unicode"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -12,9 +13,86 @@ func titleCase(s string) string {
 	return caser.String(s)
 }
 
+// ToPascalCase turns "user-profile", "user_profile" or "[...slug]" into "UserProfile" / "Slug".
 func ToPascalCase(s string) string {
-	s = strings.ReplaceAll(s, "-", " ")
-	s = strings.ReplaceAll(s, "_", " ")
+	// Anything that can't be part of an identifier separates words
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return ' '
+	}, s)
 	s = titleCase(s)
 	return strings.ReplaceAll(s, " ", "")
 }
+
+// ToCamelCase turns "user-profile" into "userProfile".
+func ToCamelCase(s string) string {
+	pascal := ToPascalCase(s)
+	if pascal == "" {
+		return ""
+	}
+	runes := []rune(pascal)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// Words splits "userProfile", "user-profile", "User_Profile" or "[...slug]" into lower case words.
+func Words(s string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(current) > 0 {
+				words = append(words, string(current))
+				current = nil
+			}
+			continue
+		}
+		// A capital starts a new word after a lower case letter or digit ("userProfile"), or
+		// before a lower case letter at the end of an acronym ("HTMLParser")
+		if unicode.IsUpper(r) && len(current) > 0 {
+			prev := runes[i-1]
+			if !unicode.IsUpper(prev) || (i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				words = append(words, string(current))
+				current = nil
+			}
+		}
+		current = append(current, unicode.ToLower(r))
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+// ToKebabCase turns "userProfile" into "user-profile".
+func ToKebabCase(s string) string {
+	return strings.Join(Words(s), "-")
+}
+
+// ToSnakeCase turns "userProfile" into "user_profile".
+func ToSnakeCase(s string) string {
+	return strings.Join(Words(s), "_")
+}
+
+// ToTitle turns "user-profile" into "User Profile".
+func ToTitle(s string) string {
+	return titleCase(strings.Join(Words(s), " "))
+}
+
+// Pluralize returns the English plural of a singular noun ("post" -> "posts", "category" -> "categories").
+func Pluralize(s string) string {
+	lower := strings.ToLower(s)
+	switch {
+	case s == "":
+		return s
+	case strings.HasSuffix(lower, "s") || strings.HasSuffix(lower, "x") || strings.HasSuffix(lower, "z") ||
+		strings.HasSuffix(lower, "ch") || strings.HasSuffix(lower, "sh"):
+		return s + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return s[:len(s)-1] + "ies"
+	}
+	return s + "s"
+}
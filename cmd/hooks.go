@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/helpers"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	hookBegin         = "# >>> nextjs-routing-helper >>>"
+	hookEnd           = "# <<< nextjs-routing-helper <<<"
+	hookShebang       = "#!/bin/sh"
+	lefthookCommandID = "nextjs-routing-helper"
+	// goInstallName is the binary name 'go install' gives the CLI
+	goInstallName = "nextjs-routing-helper-cli"
+)
+
+var lefthookConfigFiles = []string{"lefthook.yml", "lefthook.yaml", ".lefthook.yml", ".lefthook.yaml"}
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manages the git pre-commit hook that validates routes.",
+	Long: `Manages a git pre-commit hook running 'check --staged' before every commit.
+- If the project uses Husky, the check is added to '.husky/pre-commit'.
+- If the project uses lefthook, a pre-commit command is added to its config.
+- Otherwise a '.git/hooks/pre-commit' script is written (or extended).
+`,
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Installs the route check pre-commit hook.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		target, err := installHook(AppFs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error installing hook:\n%v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Pre-commit hook installed in %s\n", target)
+	},
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Removes the route check pre-commit hook.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		target, removed, err := uninstallHook(AppFs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error removing hook:\n%v\n", err)
+			os.Exit(1)
+		}
+		if !removed {
+			fmt.Println("No pre-commit hook installed.")
+			return
+		}
+		fmt.Printf("Pre-commit hook removed from %s\n", target)
+	},
+}
+
+// hookCommand is the shell command the hook runs. Stdout is dropped so only issues show up on rejection.
+// The binary is looked up under both of its install names; when neither is found the command fails.
+func hookCommand() string {
+	name := rootCmd.Name()
+	return fmt.Sprintf("$(command -v %s || command -v %s || echo %s) check --staged > /dev/null", name, goInstallName, name)
+}
+
+// hookBlock is the marked snippet added to shell hook scripts.
+func hookBlock() string {
+	name := rootCmd.Name()
+	return strings.Join([]string{
+		hookBegin,
+		fmt.Sprintf("if ! command -v %s > /dev/null 2>&1 && ! command -v %s > /dev/null 2>&1; then", name, goInstallName),
+		fmt.Sprintf("  echo \"%s is not installed, routes cannot be checked (run '%s hooks uninstall' to remove this hook)\" >&2", name, name),
+		"  exit 1",
+		"fi",
+		hookCommand() + " || exit 1",
+		hookEnd,
+	}, "\n") + "\n"
+}
+
+// insertHookBlock adds (or refreshes) the hook block in an existing script.
+func insertHookBlock(script string) string {
+	script, _ = removeHookBlock(script)
+	if script == "" {
+		script = hookShebang + "\n"
+	}
+	if !strings.HasSuffix(script, "\n") {
+		script += "\n"
+	}
+	return script + hookBlock()
+}
+
+// removeHookBlock strips the hook block from a script, reporting whether it was present.
+func removeHookBlock(script string) (string, bool) {
+	start := strings.Index(script, hookBegin)
+	if start == -1 {
+		return script, false
+	}
+	end := strings.Index(script[start:], hookEnd)
+	if end == -1 {
+		return script, false
+	}
+	end += start + len(hookEnd)
+	if end < len(script) && script[end] == '\n' {
+		end++
+	}
+	return script[:start] + script[end:], true
+}
+
+// hookScriptPath returns the shell script the hook goes into, preferring Husky over plain git hooks.
+func hookScriptPath(fs afero.Fs) (string, error) {
+	if exists, _ := afero.DirExists(fs, ".husky"); exists {
+		return filepath.Join(".husky", "pre-commit"), nil
+	}
+	dir, err := helpers.GitHooksDir()
+	if err != nil {
+		return "", fmt.Errorf("not a git repository (or git is not installed): %w", err)
+	}
+	return filepath.Join(dir, "pre-commit"), nil
+}
+
+// lefthookConfigPath returns the lefthook config in use, or "" when the project doesn't use lefthook.
+func lefthookConfigPath(fs afero.Fs) string {
+	for _, name := range lefthookConfigFiles {
+		if exists, _ := afero.Exists(fs, name); exists {
+			return name
+		}
+	}
+	return ""
+}
+
+func installHook(fs afero.Fs) (string, error) {
+	if config := lefthookConfigPath(fs); config != "" {
+		return config, updateLefthookConfig(fs, config, true)
+	}
+
+	target, err := hookScriptPath(fs)
+	if err != nil {
+		return "", err
+	}
+	var script string
+	if data, err := afero.ReadFile(fs, target); err == nil {
+		script = string(data)
+	}
+
+	if err := fs.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return "", fmt.Errorf("could not create directory '%s': %w", filepath.Dir(target), err)
+	}
+	if err := afero.WriteFile(fs, target, []byte(insertHookBlock(script)), 0755); err != nil { // rwxr-xr-x, hooks must be executable
+		return "", fmt.Errorf("could not write hook '%s': %w", target, err)
+	}
+	if err := fs.Chmod(target, 0755); err != nil {
+		return "", fmt.Errorf("could not make hook '%s' executable: %w", target, err)
+	}
+	return target, nil
+}
+
+func uninstallHook(fs afero.Fs) (string, bool, error) {
+	if config := lefthookConfigPath(fs); config != "" {
+		return config, true, updateLefthookConfig(fs, config, false)
+	}
+
+	target, err := hookScriptPath(fs)
+	if err != nil {
+		return "", false, err
+	}
+	data, err := afero.ReadFile(fs, target)
+	if err != nil {
+		return target, false, nil
+	}
+	script, removed := removeHookBlock(string(data))
+	if !removed {
+		return target, false, nil
+	}
+
+	// Delete the hook entirely when nothing but the shebang is left
+	if rest := strings.TrimSpace(script); rest == "" || rest == hookShebang {
+		if err := fs.Remove(target); err != nil {
+			return "", false, fmt.Errorf("could not remove hook '%s': %w", target, err)
+		}
+		return target, true, nil
+	}
+	if err := afero.WriteFile(fs, target, []byte(script), 0755); err != nil {
+		return "", false, fmt.Errorf("could not write hook '%s': %w", target, err)
+	}
+	return target, true, nil
+}
+
+// updateLefthookConfig adds or removes the pre-commit command in a lefthook config, keeping its comments and layout.
+func updateLefthookConfig(fs afero.Fs, path string, install bool) error {
+	data, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return fmt.Errorf("could not read '%s': %w", path, err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("could not parse '%s': %w", path, err)
+	}
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("'%s' is not a YAML mapping", path)
+	}
+
+	if install {
+		commands := yamlMapping(yamlMapping(root, "pre-commit"), "commands")
+		command := yamlMapping(commands, lefthookCommandID)
+		command.Content = []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "run"},
+			{Kind: yaml.ScalarNode, Value: hookCommand()},
+		}
+	} else if preCommit := yamlLookup(root, "pre-commit"); preCommit != nil {
+		if commands := yamlLookup(preCommit, "commands"); commands != nil {
+			yamlDelete(commands, lefthookCommandID)
+		}
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("error marshalling '%s': %w", path, err)
+	}
+	if err := afero.WriteFile(fs, path, out.Bytes(), 0644); err != nil {
+		return fmt.Errorf("could not write '%s': %w", path, err)
+	}
+	return nil
+}
+
+// yamlLookup returns the value stored under key in a mapping node, or nil.
+func yamlLookup(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlMapping returns the mapping stored under key, creating it when missing.
+func yamlMapping(mapping *yaml.Node, key string) *yaml.Node {
+	if value := yamlLookup(mapping, key); value != nil {
+		if value.Kind != yaml.MappingNode {
+			value.Kind, value.Tag, value.Value, value.Content = yaml.MappingNode, "", "", nil
+		}
+		return value
+	}
+	value := &yaml.Node{Kind: yaml.MappingNode}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
+
+// yamlDelete removes key from a mapping node.
+func yamlDelete(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHookBlock(t *testing.T) {
+	existing := "#!/bin/sh\nnpm run lint\n"
+
+	installed := insertHookBlock(existing)
+	assert.Contains(t, installed, "npm run lint")
+	assert.Contains(t, installed, hookCommand())
+
+	// Installing twice must not duplicate the block
+	assert.Equal(t, installed, insertHookBlock(installed))
+
+	removed, ok := removeHookBlock(installed)
+	assert.True(t, ok)
+	assert.Equal(t, existing, removed)
+}
+
+func TestLefthookConfig(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	original := "# project hooks\npre-commit:\n  commands:\n    lint:\n      run: npm run lint\n"
+	assert.NoError(t, afero.WriteFile(fs, "lefthook.yml", []byte(original), 0644))
+
+	target, err := installHook(fs)
+	assert.NoError(t, err)
+	assert.Equal(t, "lefthook.yml", target)
+
+	data, _ := afero.ReadFile(fs, "lefthook.yml")
+	assert.Contains(t, string(data), "# project hooks")
+	assert.Contains(t, string(data), "run: "+hookCommand())
+
+	_, removed, err := uninstallHook(fs)
+	assert.NoError(t, err)
+	assert.True(t, removed)
+
+	data, _ = afero.ReadFile(fs, "lefthook.yml")
+	assert.Equal(t, original, string(data))
+}
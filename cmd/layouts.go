@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+// Boundaries the layouts report resolves for every page, in print order.
+var boundaryNames = []string{"loading", "error", "not-found"}
+
+var layoutsCmd = &cobra.Command{
+	Use:   "layouts --flag",
+	Short: "Reports the layouts and boundaries wrapping each page.",
+	Long: `Prints every page of the app router with its resolved layout chain (root to leaf),
+its template files and the nearest loading, error and not-found boundaries.
+- Use --missing to list only the pages without a given boundary (e.g. '--missing error').
+- Use --json for machine readable output.
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		missingFlag, _ := cmd.Flags().GetString("missing")
+		jsonFlag, _ := cmd.Flags().GetBool("json")
+
+		config, err := constants.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+			os.Exit(1)
+		}
+		if config.Router != constants.AppRouter {
+			fmt.Fprintln(os.Stderr, "Layouts and boundaries are only supported by the app router.")
+			os.Exit(1)
+		}
+		if missingFlag != "" && !isBoundary(missingFlag) {
+			fmt.Fprintf(os.Stderr, "Invalid boundary '%s', expected one of: %s\n", missingFlag, strings.Join(boundaryNames, ", "))
+			os.Exit(1)
+		}
+
+		found, err := routes.Scan(AppFs, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning routes:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		reports := layoutReports(AppFs, config, found)
+		if missingFlag != "" {
+			reports = missingBoundary(reports, missingFlag)
+		}
+
+		if jsonFlag {
+			data, _ := json.MarshalIndent(reports, "", "  ")
+			fmt.Println(string(data))
+			return
+		}
+		if missingFlag != "" {
+			if len(reports) == 0 {
+				fmt.Printf("Every page has a %s boundary.\n", missingFlag)
+				return
+			}
+			fmt.Printf("Pages without a %s boundary (%d):\n", missingFlag, len(reports))
+			for _, report := range reports {
+				fmt.Printf("  %s  %s\n", report.URL, report.File)
+			}
+			return
+		}
+		printLayoutReports(reports)
+	},
+}
+
+// LayoutReport is the layout chain and nearest boundaries of a page.
+type LayoutReport struct {
+	URL        string            `json:"url"`
+	File       string            `json:"file"`
+	Layouts    []string          `json:"layouts"`
+	Templates  []string          `json:"templates"`
+	Boundaries map[string]string `json:"boundaries"`
+}
+
+func isBoundary(name string) bool {
+	for _, boundary := range boundaryNames {
+		if name == boundary {
+			return true
+		}
+	}
+	return false
+}
+
+// layoutReports resolves the layouts, templates and nearest boundaries of every page.
+func layoutReports(fs afero.Fs, config *constants.Config, found []routes.Route) []LayoutReport {
+	reports := []LayoutReport{}
+	globalError := routes.FindSource(fs, config.RoutesDir(), "global-error")
+	for _, route := range found {
+		if route.Kind != routes.PageKind {
+			continue
+		}
+		report := LayoutReport{
+			URL:        route.URL,
+			File:       route.File,
+			Layouts:    nonNil(routes.Chain(fs, config, route.Dir, "layout")),
+			Templates:  nonNil(routes.Chain(fs, config, route.Dir, "template")),
+			Boundaries: make(map[string]string),
+		}
+		for _, name := range boundaryNames {
+			if chain := routes.Chain(fs, config, route.Dir, name); len(chain) > 0 {
+				report.Boundaries[name] = chain[len(chain)-1]
+			}
+		}
+		// global-error catches what no error boundary does, including errors of the root layout
+		if report.Boundaries["error"] == "" && globalError != "" {
+			report.Boundaries["error"] = globalError
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// missingBoundary keeps the reports without the given boundary anywhere in their ancestor chain.
+func missingBoundary(reports []LayoutReport, name string) []LayoutReport {
+	missing := []LayoutReport{}
+	for _, report := range reports {
+		if report.Boundaries[name] == "" {
+			missing = append(missing, report)
+		}
+	}
+	return missing
+}
+
+func nonNil(files []string) []string {
+	if files == nil {
+		return []string{}
+	}
+	return files
+}
+
+func printLayoutReports(reports []LayoutReport) {
+	orNone := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	for _, report := range reports {
+		fmt.Printf("%s  %s\n", report.URL, report.File)
+		fmt.Printf("  layouts:   %s\n", orNone(strings.Join(report.Layouts, " → ")))
+		fmt.Printf("  templates: %s\n", orNone(strings.Join(report.Templates, " → ")))
+		for _, name := range boundaryNames {
+			fmt.Printf("  %-10s %s\n", name+":", orNone(report.Boundaries[name]))
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(layoutsCmd)
+	layoutsCmd.Flags().String("missing", "", "Only list pages without this boundary (loading, error or not-found)")
+	layoutsCmd.Flags().Bool("json", false, "Print the report as JSON")
+}
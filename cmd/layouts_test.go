@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayoutReports(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	config := &constants.Config{Router: constants.AppRouter}
+	for _, file := range []string{
+		"app/layout.tsx",
+		"app/page.tsx",
+		"app/not-found.tsx",
+		"app/dashboard/layout.tsx",
+		"app/dashboard/error.tsx",
+		"app/dashboard/template.tsx",
+		"app/dashboard/settings/loading.tsx",
+		"app/dashboard/settings/page.tsx",
+		"app/api/users/route.ts",
+	} {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(file), []byte(""), 0644))
+	}
+
+	found, err := routes.Scan(fs, config)
+	assert.NoError(t, err)
+	reports := layoutReports(fs, config, found)
+	assert.Len(t, reports, 2)
+
+	settings := reports[1]
+	assert.Equal(t, "/dashboard/settings", settings.URL)
+	assert.Equal(t, []string{filepath.Join("app", "layout.tsx"), filepath.Join("app", "dashboard", "layout.tsx")}, settings.Layouts)
+	assert.Equal(t, []string{filepath.Join("app", "dashboard", "template.tsx")}, settings.Templates)
+	assert.Equal(t, map[string]string{
+		"loading":   filepath.Join("app", "dashboard", "settings", "loading.tsx"),
+		"error":     filepath.Join("app", "dashboard", "error.tsx"),
+		"not-found": filepath.Join("app", "not-found.tsx"),
+	}, settings.Boundaries)
+
+	missing := missingBoundary(reports, "error")
+	assert.Len(t, missing, 1)
+	assert.Equal(t, "/", missing[0].URL)
+
+	// The root global-error is the error boundary of pages without a nearer one
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join("app", "global-error.tsx"), []byte(""), 0644))
+	reports = layoutReports(fs, config, found)
+	assert.Equal(t, filepath.Join("app", "global-error.tsx"), reports[0].Boundaries["error"])
+	assert.Equal(t, filepath.Join("app", "dashboard", "error.tsx"), reports[1].Boundaries["error"])
+	assert.Empty(t, missingBoundary(reports, "error"))
+}
+
+func TestLayoutsJSONOutput(t *testing.T) {
+	dir := t.TempDir()
+	for file, content := range map[string]string{
+		constants.ConfigFileName: `{"router": "app", "language": "ts", "componentStyle": "function"}`,
+		"app/layout.tsx":         `export default function RootLayout() {}`,
+		"app/page.tsx":           `export default function Home() {}`,
+		"app/blog/error.tsx":     `'use client';`,
+		"app/blog/page.tsx":      `export default function Blog() {}`,
+	} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(file)), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0644))
+	}
+
+	var reports []LayoutReport
+	assert.NoError(t, json.Unmarshal([]byte(executeCommand(t, dir, "layouts", "--json", "--missing", "error")), &reports))
+	assert.Len(t, reports, 1)
+	assert.Equal(t, "/", reports[0].URL)
+}
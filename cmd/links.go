@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var linksCmd = &cobra.Command{
+	Use:   "links --flag",
+	Short: "Finds dead internal links in your Next.js project.",
+	Long: `Scans source files for static hrefs in <Link>, <a>, router.push, redirect and permanentRedirect calls.
+- Every href is resolved against the routes, including dynamic segments.
+- Links that match no route (or file in 'public') are reported with file and line numbers.
+- Pages that nothing links to are listed as well.
+Exits with a non-zero status when dead links are found.
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		jsonFlag, _ := cmd.Flags().GetBool("json")
+
+		config, err := constants.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		found, err := routes.Scan(AppFs, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning routes:\n%v\n", err)
+			os.Exit(1)
+		}
+		links, err := routes.FindLinks(AppFs, ".")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning links:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		report := LinkReport{
+			Dead:     deadLinks(AppFs, found, links),
+			Unlinked: unlinkedRoutes(found, links),
+		}
+
+		if jsonFlag {
+			data, _ := json.MarshalIndent(report, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			printLinkReport(report)
+		}
+		if len(report.Dead) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// LinkReport is the result of the links command.
+type LinkReport struct {
+	Dead     []routes.Link  `json:"dead"`
+	Unlinked []routes.Route `json:"unlinked"`
+}
+
+// deadLinks returns the links that neither resolve to a route nor point at a file in 'public'.
+func deadLinks(fs afero.Fs, found []routes.Route, links []routes.Link) []routes.Link {
+	dead := []routes.Link{}
+	for _, link := range links {
+		if routes.Resolve(found, link.Href) != nil {
+			continue
+		}
+		publicFile := filepath.Join("public", filepath.FromSlash(routes.CleanURL(link.Href)))
+		if exists, _ := afero.Exists(fs, publicFile); exists {
+			continue
+		}
+		dead = append(dead, link)
+	}
+	return dead
+}
+
+// unlinkedRoutes returns the pages no link resolves to. The root page and API routes are never reported.
+func unlinkedRoutes(found []routes.Route, links []routes.Link) []routes.Route {
+	linked := make(map[string]bool)
+	for _, link := range links {
+		if target := routes.Resolve(found, link.Href); target != nil {
+			linked[target.File] = true
+		}
+	}
+
+	unlinked := []routes.Route{}
+	for _, route := range found {
+		if route.Kind == routes.PageKind && route.URL != "/" && !linked[route.File] {
+			unlinked = append(unlinked, route)
+		}
+	}
+	return unlinked
+}
+
+func printLinkReport(report LinkReport) {
+	if len(report.Dead) == 0 {
+		fmt.Println("No dead links found.")
+	} else {
+		fmt.Printf("Dead links (%d):\n", len(report.Dead))
+		for _, link := range report.Dead {
+			fmt.Printf("  %s:%d  %s  (%s)\n", link.File, link.Line, link.Href, link.Source)
+		}
+	}
+
+	if len(report.Unlinked) > 0 {
+		fmt.Printf("Routes nothing links to (%d):\n", len(report.Unlinked))
+		for _, route := range report.Unlinked {
+			fmt.Printf("  %s  %s\n", route.URL, route.File)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(linksCmd)
+	linksCmd.Flags().Bool("json", false, "Print the report as JSON")
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkReport(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join("public", "logo.svg"), []byte(""), 0644))
+
+	found := []routes.Route{
+		{URL: "/", File: "app/page.tsx", Kind: routes.PageKind},
+		{URL: "/blog/[slug]", File: "app/blog/[slug]/page.tsx", Kind: routes.PageKind},
+		{URL: "/about", File: "app/about/page.tsx", Kind: routes.PageKind},
+		{URL: "/api/users", File: "app/api/users/route.ts", Kind: routes.APIKind},
+	}
+	links := []routes.Link{
+		{File: "nav.tsx", Line: 1, Href: "/blog/hello"},
+		{File: "nav.tsx", Line: 2, Href: "/blgo/hello"},
+		{File: "nav.tsx", Line: 3, Href: "/logo.svg"},
+	}
+
+	assert.Equal(t, []routes.Link{links[1]}, deadLinks(fs, found, links))
+	assert.Equal(t, []routes.Route{found[2]}, unlinkedRoutes(found, links))
+}
+
+func TestLinksJSONOutput(t *testing.T) {
+	dir := t.TempDir()
+	for file, content := range map[string]string{
+		constants.ConfigFileName: `{"router": "app", "language": "ts", "componentStyle": "function"}`,
+		"app/page.tsx":           `export default function Home() { return <Link href="/about">About</Link>; }`,
+		"app/about/page.tsx":     `export default function About() {}`,
+	} {
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(file)), 0755))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0644))
+	}
+
+	var report LinkReport
+	assert.NoError(t, json.Unmarshal([]byte(executeCommand(t, dir, "links", "--json")), &report))
+	assert.Empty(t, report.Dead)
+}
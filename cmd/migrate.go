@@ -0,0 +1,336 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/helpers"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// import x from './x', import './x.css', export * from './x', import('./x'), require('./x')
+	relativeImportPattern = regexp.MustCompile(`((?:from|import)\s*\(?\s*|require\(\s*)(['"])(\.\.?/[^'"]*)(['"])`)
+	// import './globals.css'
+	sideEffectImportPattern = regexp.MustCompile(`(?m)^import\s+['"][^'"]+['"];?[ \t]*$`)
+)
+
+// Pages router APIs that have no automatic App Router equivalent, with how to convert them.
+var migrationChecks = []struct {
+	pattern *regexp.Regexp
+	message string
+}{
+	{regexp.MustCompile(`\bgetServerSideProps\b`), "getServerSideProps is not supported, fetch the data in the (async) page component instead"},
+	{regexp.MustCompile(`\bgetStaticProps\b`), "getStaticProps is not supported, fetch the data in the page component and export 'revalidate' if needed"},
+	{regexp.MustCompile(`\bgetStaticPaths\b`), "getStaticPaths is not supported, replace it with generateStaticParams"},
+	{regexp.MustCompile(`\bgetInitialProps\b`), "getInitialProps is not supported, fetch the data in the page component instead"},
+	{regexp.MustCompile(`['"]next/router['"]`), "next/router is not supported, use useRouter, usePathname and useSearchParams from next/navigation"},
+	{regexp.MustCompile(`['"]next/head['"]`), "next/head is not supported, use the metadata export or generateMetadata"},
+	{regexp.MustCompile(`['"]next/amp['"]`), "AMP pages are not supported by the app router"},
+}
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrates your Next.js project between conventions.",
+}
+
+var migratePagesToAppCmd = &cobra.Command{
+	Use:   "pages-to-app --flag",
+	Short: "Migrates the pages directory to the app router.",
+	Long: `Generates the app router equivalent of every file in the pages directory:
+- 'pages/blog/[slug].tsx' becomes 'app/blog/[slug]/page.tsx', carrying the component over.
+- '_app' and '_document' become the root 'layout.tsx'.
+- 'pages/api/*' become 'route.ts' handlers.
+- '404' becomes 'not-found.tsx', '_error' and '500' become 'error.tsx'.
+The pages directory is left untouched. A report lists what needs to be converted by hand
+(getServerSideProps, getStaticPaths, next/router, ...).
+- Use --dry-run to only print the report.
+- Use --report to also write the report as a markdown file.
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRunFlag, _ := cmd.Flags().GetBool("dry-run")
+		reportFlag, _ := cmd.Flags().GetString("report")
+
+		config, err := constants.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		migration, err := planMigration(AppFs, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error planning migration:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		if !dryRunFlag {
+			for _, file := range migration.Files {
+				if err := createPageFile(AppFs, file.To, file.Content); err != nil {
+					fmt.Fprintf(os.Stderr, "Error writing %s:\n%v\n", file.To, err)
+					os.Exit(1)
+				}
+			}
+		}
+
+		report := migration.Report()
+		fmt.Print(report)
+		if reportFlag != "" {
+			if err := createPageFile(AppFs, reportFlag, report); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing report:\n%v\n", err)
+				os.Exit(1)
+			}
+		}
+		if !dryRunFlag && len(migration.Files) > 0 {
+			fmt.Println("\nOnce the pages directory is removed, set \"router\" to \"app\" in " + constants.ConfigFileName + ".")
+		}
+	},
+}
+
+// MigratedFile is an app router file generated from one or more pages router files.
+type MigratedFile struct {
+	From    []string
+	To      string
+	Content string
+}
+
+// MigrationNote is something in a pages router file that has to be converted by hand.
+type MigrationNote struct {
+	File    string
+	Message string
+}
+
+// Migration is the set of files to generate and the notes of a pages to app router migration.
+type Migration struct {
+	Files []MigratedFile
+	Notes []MigrationNote
+}
+
+// planMigration maps every file of the pages directory to its app router location.
+func planMigration(fs afero.Fs, config *constants.Config) (*Migration, error) {
+	pagesConfig, appConfig := *config, *config
+	pagesConfig.Router = constants.PagesRouter
+	appConfig.Router = constants.AppRouter
+	pagesDir, appDir := pagesConfig.RoutesDir(), appConfig.RoutesDir()
+
+	if exists, _ := afero.DirExists(fs, pagesDir); !exists {
+		return nil, fmt.Errorf("pages directory '%s' does not exist", pagesDir)
+	}
+
+	migration := &Migration{}
+	var rootSources, rootImports []string
+
+	err := afero.Walk(fs, pagesDir, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !routes.IsSourceFile(info.Name()) {
+			return nil
+		}
+		content, err := afero.ReadFile(fs, file)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(pagesDir, file)
+		name := filepath.ToSlash(strings.TrimSuffix(rel, filepath.Ext(rel)))
+
+		switch {
+		case name == "_app" || name == "_document":
+			// Both are folded into the root layout, only global imports (e.g. CSS) carry over
+			rootSources = append(rootSources, file)
+			for _, line := range sideEffectImportPattern.FindAllString(string(content), -1) {
+				rootImports = append(rootImports, rewriteRelativeImports(line, filepath.Dir(file), appDir))
+			}
+			if name == "_app" {
+				migration.note(file, "custom App, move providers and shared UI into the root layout")
+			} else {
+				migration.note(file, "custom Document, move <html> and <body> attributes into the root layout")
+			}
+			return nil
+
+		case name == "_error" || name == "500":
+			to := specialFilePath(&appConfig, appDir, "error")
+			body, err := generateSpecialFileContent("error", appDir, &appConfig)
+			if err != nil {
+				return err
+			}
+			migration.add(file, to, body)
+			migration.note(file, fmt.Sprintf("custom error page, port its UI to %s", to))
+			return nil
+
+		case name == "api" || strings.HasPrefix(name, "api/"):
+			return migration.migrateAPIRoute(file, name, content, &appConfig)
+
+		case name == "404":
+			to := specialFilePath(&appConfig, appDir, "not-found")
+			data := SpecialFileData{
+				ComponentName: "NotFound",
+				Style:         appConfig.ComponentStyle,
+				Language:      appConfig.Language,
+				Root:          true,
+				Body:          migratedBody(content, filepath.Dir(file), appDir),
+			}
+			body, err := generateFileContent("not-found", data)
+			if err != nil {
+				return err
+			}
+			migration.add(file, to, body)
+			migration.check(file, content)
+			return nil
+		}
+
+		location := strings.TrimSuffix(name, "/index")
+		if location == "index" {
+			location = ""
+		}
+		dir := filepath.Join(appDir, filepath.FromSlash(location))
+		to := filepath.Join(dir, "page"+componentExtension(&appConfig))
+		body, err := generateFileContent("page", PageData{
+			ComponentName: helpers.ToPascalCase(filepath.Base(dir)),
+			Style:         appConfig.ComponentStyle,
+			Body:          migratedBody(content, filepath.Dir(file), dir),
+		})
+		if err != nil {
+			return err
+		}
+		migration.add(file, to, body)
+		migration.check(file, content)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rootSources) > 0 {
+		to := specialFilePath(&appConfig, appDir, "layout")
+		body, err := generateSpecialFileContent("layout", appDir, &appConfig)
+		if err != nil {
+			return nil, err
+		}
+		if len(rootImports) > 0 {
+			body = strings.Join(rootImports, "\n") + "\n\n" + body
+		}
+		migration.Files = append(migration.Files, MigratedFile{From: rootSources, To: to, Content: body})
+	}
+
+	// Never overwrite files that already exist in the app directory, nor files generated
+	// from another source (e.g. both '_error' and '500' map to error.tsx)
+	var files []MigratedFile
+	planned := make(map[string]MigratedFile)
+	for _, file := range migration.Files {
+		if exists, _ := afero.Exists(fs, file.To); exists {
+			migration.note(file.From[0], fmt.Sprintf("%s already exists, it was left untouched", file.To))
+			continue
+		}
+		if first, ok := planned[file.To]; ok {
+			migration.note(file.From[0], fmt.Sprintf("%s is also generated from %s, merge this file into it by hand",
+				file.To, strings.Join(first.From, ", ")))
+			continue
+		}
+		planned[file.To] = file
+		files = append(files, file)
+	}
+	migration.Files = files
+	return migration, nil
+}
+
+// migrateAPIRoute generates the route handler of a pages/api file and keeps the original
+// handler as a comment, as its req/res signature cannot be converted automatically.
+func (m *Migration) migrateAPIRoute(file, name string, content []byte, appConfig *constants.Config) error {
+	input := strings.TrimSuffix(name, "/index")
+	to := routeHandlerPath(appConfig, input)
+	body, err := generateRouteHandlerContent(input, appConfig)
+	if err != nil {
+		return err
+	}
+	original := strings.ReplaceAll(strings.TrimSpace(string(content)), "*/", "*\\/")
+	body += "\n\n/*\n * Original pages router handler:\n *\n" + prefixLines(original, " * ") + "\n */\n"
+	m.add(file, to, body)
+	m.note(file, "API route, rewrite the default handler as named GET, POST, ... exports")
+	return nil
+}
+
+func (m *Migration) add(from, to, content string) {
+	m.Files = append(m.Files, MigratedFile{From: []string{from}, To: to, Content: content})
+}
+
+func (m *Migration) note(file, message string) {
+	m.Notes = append(m.Notes, MigrationNote{File: file, Message: message})
+}
+
+// check notes every pages router API used by the file.
+func (m *Migration) check(file string, content []byte) {
+	for _, check := range migrationChecks {
+		if check.pattern.Match(content) {
+			m.note(file, check.message)
+		}
+	}
+}
+
+// Report renders the migration as markdown.
+func (m *Migration) Report() string {
+	var b strings.Builder
+	b.WriteString("# Pages to App Router migration\n\n")
+	fmt.Fprintf(&b, "## Generated files (%d)\n\n", len(m.Files))
+	for _, file := range m.Files {
+		fmt.Fprintf(&b, "- %s ← %s\n", filepath.ToSlash(file.To), filepath.ToSlash(strings.Join(file.From, ", ")))
+	}
+	fmt.Fprintf(&b, "\n## Manual steps (%d)\n\n", len(m.Notes))
+	for _, note := range m.Notes {
+		fmt.Fprintf(&b, "- %s: %s\n", filepath.ToSlash(note.File), note.Message)
+	}
+	return b.String()
+}
+
+// migratedBody carries a component over to the app directory: relative imports are
+// rewritten for its new location and it is marked 'use client' when it needs to be.
+func migratedBody(content []byte, fromDir, toDir string) string {
+	body := rewriteRelativeImports(string(content), fromDir, toDir)
+	if len(routes.ClientFeatures(content, false)) > 0 {
+		body = routes.AddDirective(body, "use client")
+	}
+	return body
+}
+
+// rewriteRelativeImports makes the relative import paths of content resolve from toDir.
+func rewriteRelativeImports(content, fromDir, toDir string) string {
+	from, to := filepath.ToSlash(fromDir), filepath.ToSlash(toDir)
+	return relativeImportPattern.ReplaceAllStringFunc(content, func(match string) string {
+		m := relativeImportPattern.FindStringSubmatch(match)
+		target := path.Join(from, m[3])
+		rel, err := filepath.Rel(filepath.FromSlash(to), filepath.FromSlash(target))
+		if err != nil {
+			return match
+		}
+		rel = filepath.ToSlash(rel)
+		if !strings.HasPrefix(rel, "../") {
+			rel = "./" + rel
+		}
+		if strings.HasSuffix(m[3], "/") && !strings.HasSuffix(rel, "/") {
+			rel += "/"
+		}
+		return m[1] + m[2] + rel + m[4]
+	})
+}
+
+func prefixLines(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(prefix+line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migratePagesToAppCmd)
+	migratePagesToAppCmd.Flags().Bool("dry-run", false, "Only print the migration report without writing files")
+	migratePagesToAppCmd.Flags().String("report", "", "Also write the migration report to this markdown file")
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanMigration(t *testing.T) {
+	fs := useTemplateFs(t)
+	config := &constants.Config{Router: constants.PagesRouter, Language: constants.Typescript, ComponentStyle: constants.Function}
+	files := map[string]string{
+		"pages/_app.tsx":        "import '../styles/globals.css';\nimport type { AppProps } from 'next/app';\n\nexport default function App({ Component, pageProps }: AppProps) {\n  return <Component {...pageProps} />;\n}",
+		"pages/_document.tsx":   "export default function Document() {}",
+		"pages/index.tsx":       "export default function Home() {\n  return <h1>Home</h1>;\n}",
+		"pages/404.tsx":         "export default function Custom404() {\n  return <h1>Gone</h1>;\n}",
+		"pages/500.tsx":         "export default function Custom500() {}",
+		"pages/_error.tsx":      "export default function Error() {}",
+		"pages/blog/[slug].tsx": "import Post from '../../components/Post';\nimport { useRouter } from 'next/router';\n\nexport default function BlogPost() {\n  const router = useRouter();\n  return <Post slug={router.query.slug} />;\n}\n\nexport async function getStaticPaths() {}\n",
+		"pages/api/users.ts":    "export default function handler(req, res) {\n  res.json([]);\n}",
+		"app/page.tsx":          "export default function Existing() {}",
+	}
+	for path, content := range files {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(path), []byte(content), 0644))
+	}
+
+	migration, err := planMigration(fs, config)
+	assert.NoError(t, err)
+
+	generated := make(map[string]string)
+	for _, file := range migration.Files {
+		generated[filepath.ToSlash(file.To)] = file.Content
+	}
+	assert.NotContains(t, generated, "app/page.tsx")
+	assert.Equal(t, files["pages/404.tsx"], generated["app/not-found.tsx"])
+	assert.Equal(t, "'use client';\n\nimport Post from '../../../components/Post';\nimport { useRouter } from 'next/router';\n\nexport default function BlogPost() {\n  const router = useRouter();\n  return <Post slug={router.query.slug} />;\n}\n\nexport async function getStaticPaths() {}\n", generated["app/blog/[slug]/page.tsx"])
+	assert.Contains(t, generated["app/api/users/route.ts"], "export async function GET(request: Request)")
+	assert.Contains(t, generated["app/api/users/route.ts"], " *   res.json([]);")
+	assert.Contains(t, generated["app/layout.tsx"], "import '../styles/globals.css';\n\nexport default function RootLayout")
+
+	report := migration.Report()
+	assert.Contains(t, report, "pages/blog/[slug].tsx: getStaticPaths is not supported")
+	assert.Contains(t, report, "pages/blog/[slug].tsx: next/router is not supported")
+	assert.Contains(t, report, "pages/_document.tsx: custom Document")
+	assert.Contains(t, report, "pages/index.tsx: app/page.tsx already exists")
+
+	// Two sources of the same file are reported instead of overwriting each other
+	var errorFiles int
+	for _, file := range migration.Files {
+		if filepath.ToSlash(file.To) == "app/error.tsx" {
+			errorFiles++
+		}
+	}
+	assert.Equal(t, 1, errorFiles)
+	assert.Contains(t, report, "pages/_error.tsx: app/error.tsx is also generated from pages/500.tsx, merge this file into it by hand")
+}
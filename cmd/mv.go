@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var mvCmd = &cobra.Command{
+	Use:   "mv [from] [to] --flag",
+	Short: "Moves or renames a page in your Next.js project.",
+	Long: `Moves a page to a new route and keeps the project consistent.
+- The route directory is moved with all its colocated files and child routes.
+- The page component is renamed (e.g., 'ProfilePage' to 'AccountPage').
+- String literal hrefs, redirect() and router.push() calls pointing at the old URL are rewritten.
+- A permanent redirect from the old URL can be added to next.config.
+`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		redirectFlag, _ := cmd.Flags().GetBool("redirect")
+
+		config, err := constants.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+			fmt.Fprintln(os.Stderr, "Please run 'nextjs-routing-helper-cli init' first.")
+			os.Exit(1)
+		}
+
+		found, err := routes.Scan(AppFs, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning routes:\n%v\n", err)
+			os.Exit(1)
+		}
+		route := findRoute(found, config, args[0])
+		if route == nil {
+			fmt.Fprintf(os.Stderr, "Error: no page found for '%s'\n", args[0])
+			os.Exit(1)
+		}
+
+		result, err := moveRoute(AppFs, config, found, *route, args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error moving page:\n%v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Moved %s to %s\n", result.FromURL, result.ToURL)
+		for _, file := range result.Rewritten {
+			fmt.Printf("- updated links in %s\n", file)
+		}
+
+		if !redirectFlag {
+			fmt.Printf("Add a redirect from %s to %s in next.config? (y/N): ", result.FromURL, result.ToURL)
+			choice, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			redirectFlag = strings.EqualFold(strings.TrimSpace(choice), "y")
+		}
+		if redirectFlag {
+			file, err := addRedirect(AppFs, result.FromURL, result.ToURL)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error adding redirect:\n%v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Redirect added to %s\n", file)
+		}
+	},
+}
+
+// MoveResult describes what moveRoute changed.
+type MoveResult struct {
+	FromURL   string
+	ToURL     string
+	Rewritten []string
+}
+
+// moveRoute moves a route to a new location, renames its component and rewrites links to it.
+func moveRoute(fs afero.Fs, config *constants.Config, found []routes.Route, route routes.Route, toInput string) (*MoveResult, error) {
+	toInput = strings.Trim(filepath.ToSlash(toInput), "/")
+	if toInput == "" {
+		return nil, fmt.Errorf("destination cannot be empty or just slashes")
+	}
+
+	// Work out what moves where
+	var src, dst string
+	if ownsDir(config, route) {
+		src = route.Dir
+		dst = filepath.Join(config.RoutesDir(), filepath.FromSlash(toInput))
+	} else {
+		src = route.File
+		dst = filepath.Join(config.RoutesDir(), filepath.FromSlash(toInput)+filepath.Ext(route.File))
+	}
+	if src == config.RoutesDir() {
+		return nil, fmt.Errorf("the root page cannot be moved")
+	}
+	if strings.HasPrefix(dst+string(filepath.Separator), src+string(filepath.Separator)) {
+		return nil, fmt.Errorf("cannot move '%s' into itself", src)
+	}
+	if exists, _ := afero.Exists(fs, dst); exists {
+		return nil, fmt.Errorf("destination '%s' already exists", dst)
+	}
+
+	result := &MoveResult{FromURL: route.URL, ToURL: "/" + toInput}
+	if config.Router == constants.AppRouter {
+		result.ToURL = routes.AppURL(config.RoutesDir(), dst)
+	}
+
+	// Collect links before anything moves, while they still resolve to the old routes
+	links, err := routes.FindLinks(fs, ".")
+	if err != nil {
+		return nil, fmt.Errorf("could not scan links: %w", err)
+	}
+	var moved []routes.Link
+	for _, link := range links {
+		target := routes.Resolve(found, link.Href)
+		if target != nil && (target.File == route.File || (src == route.Dir && strings.HasPrefix(target.File, src+string(filepath.Separator)))) {
+			moved = append(moved, link)
+		}
+	}
+
+	// Move the files
+	if err := fs.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return nil, fmt.Errorf("could not create directory '%s': %w", filepath.Dir(dst), err)
+	}
+	if err := fs.Rename(src, dst); err != nil {
+		return nil, fmt.Errorf("could not move '%s' to '%s': %w", src, dst, err)
+	}
+	if err := pruneEmptyDirs(fs, filepath.Dir(src), config.RoutesDir()); err != nil {
+		return nil, err
+	}
+
+	// Rename the page component
+	movedFile := filepath.Join(dst, filepath.Base(route.File))
+	if src == route.File {
+		movedFile = dst
+	}
+	if err := renameComponent(fs, config, movedFile, route.URL, result.ToURL); err != nil {
+		return nil, err
+	}
+
+	// Rewrite the links
+	rewritten, err := rewriteLinks(fs, moved, route.URL, result.ToURL)
+	if err != nil {
+		return nil, err
+	}
+	result.Rewritten = rewritten
+	return result, nil
+}
+
+// renameComponent renames the page component in file from the name derived from oldURL to the one derived from newURL.
+func renameComponent(fs afero.Fs, config *constants.Config, file string, oldURL, newURL string) error {
+	// The root page has no segment to derive a component name from
+	if oldURL == "/" || newURL == "/" {
+		return nil
+	}
+	_, oldName, err := determinePathAndComponent(strings.TrimPrefix(oldURL, "/"), config)
+	if err != nil {
+		return nil
+	}
+	_, newName, err := determinePathAndComponent(strings.TrimPrefix(newURL, "/"), config)
+	if err != nil || oldName == newName {
+		return nil
+	}
+
+	data, err := afero.ReadFile(fs, file)
+	if err != nil {
+		return fmt.Errorf("could not read file '%s': %w", file, err)
+	}
+	pattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(oldName) + `\b`)
+	updated := pattern.ReplaceAllString(string(data), newName)
+	if updated == string(data) {
+		return nil
+	}
+	if err := afero.WriteFile(fs, file, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("could not write file '%s': %w", file, err)
+	}
+	return nil
+}
+
+// rewriteHref maps an href served under oldURL to the same location under newURL, carrying
+// dynamic segment values over in order. It reports false when the href is not under oldURL.
+func rewriteHref(href string, oldURL, newURL string) (string, bool) {
+	path, suffix := href, ""
+	if i := strings.IndexAny(href, "?#"); i != -1 {
+		path, suffix = href[:i], href[i:]
+	}
+	hrefParts := routes.SplitURL(path)
+	oldParts := routes.SplitURL(oldURL)
+
+	var values []string
+	consumed := 0
+	for _, raw := range oldParts {
+		seg, err := routes.ParseSegment(raw)
+		if err != nil {
+			return "", false
+		}
+		if seg.CatchAll {
+			values = append(values, strings.Join(hrefParts[consumed:], "/"))
+			consumed = len(hrefParts)
+			break
+		}
+		if consumed >= len(hrefParts) {
+			return "", false
+		}
+		if seg.Dynamic {
+			values = append(values, hrefParts[consumed])
+		} else if raw != hrefParts[consumed] {
+			return "", false
+		}
+		consumed++
+	}
+
+	var newParts []string
+	for _, raw := range routes.SplitURL(newURL) {
+		seg, err := routes.ParseSegment(raw)
+		if err != nil {
+			return "", false
+		}
+		if !seg.Dynamic {
+			newParts = append(newParts, raw)
+			continue
+		}
+		if len(values) == 0 {
+			return "", false
+		}
+		if values[0] != "" {
+			newParts = append(newParts, values[0])
+		}
+		values = values[1:]
+	}
+	newParts = append(newParts, hrefParts[consumed:]...)
+	return "/" + strings.Join(newParts, "/") + suffix, true
+}
+
+// rewriteLinks rewrites the given links in place, returning the files that changed.
+func rewriteLinks(fs afero.Fs, links []routes.Link, oldURL, newURL string) ([]string, error) {
+	byFile := make(map[string][]routes.Link)
+	var files []string
+	for _, link := range links {
+		if _, seen := byFile[link.File]; !seen {
+			files = append(files, link.File)
+		}
+		byFile[link.File] = append(byFile[link.File], link)
+	}
+
+	var rewritten []string
+	for _, file := range files {
+		data, err := afero.ReadFile(fs, file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read file '%s': %w", file, err)
+		}
+		lines := strings.Split(string(data), "\n")
+		changed := false
+		for _, link := range byFile[file] {
+			newHref, ok := rewriteHref(link.Href, oldURL, newURL)
+			if !ok || newHref == link.Href || link.Line > len(lines) {
+				continue
+			}
+			line := lines[link.Line-1]
+			for _, quote := range []string{`"`, `'`, "`"} {
+				line = strings.ReplaceAll(line, quote+link.Href+quote, quote+newHref+quote)
+			}
+			if line != lines[link.Line-1] {
+				lines[link.Line-1] = line
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		if err := afero.WriteFile(fs, file, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+			return nil, fmt.Errorf("could not write file '%s': %w", file, err)
+		}
+		rewritten = append(rewritten, file)
+	}
+	return rewritten, nil
+}
+
+func init() {
+	rootCmd.AddCommand(mvCmd)
+	mvCmd.Flags().Bool("redirect", false, "Add a permanent redirect from the old URL to next.config without asking")
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteHref(t *testing.T) {
+	tests := []struct {
+		href, oldURL, newURL string
+		expected             string
+		ok                   bool
+	}{
+		{"/settings/profile", "/settings/profile", "/account/profile", "/account/profile", true},
+		{"/settings/profile/edit?x=1", "/settings/profile", "/account/profile", "/account/profile/edit?x=1", true},
+		{"/blog/hello", "/blog/[slug]", "/posts/[id]", "/posts/hello", true},
+		{"/docs/a/b", "/docs/[...rest]", "/guides/[...rest]", "/guides/a/b", true},
+		{"/settings", "/settings/profile", "/account/profile", "", false},
+	}
+	for _, tt := range tests {
+		actual, ok := rewriteHref(tt.href, tt.oldURL, tt.newURL)
+		assert.Equal(t, tt.ok, ok, tt.href)
+		assert.Equal(t, tt.expected, actual, tt.href)
+	}
+}
+
+func TestMoveRoute(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	config := &constants.Config{Router: constants.AppRouter, PageComponentSuffix: "page"}
+	files := map[string]string{
+		"app/settings/profile/page.tsx":   "export default function ProfilePage() {}",
+		"app/settings/profile/Avatar.tsx": "",
+		"app/settings/page.tsx":           "",
+		"components/Nav.tsx":              `<Link href="/settings/profile">Profile</Link> <Link href="/settings">Settings</Link>`,
+		"next.config.js":                  "const nextConfig = {\n};\nmodule.exports = nextConfig;\n",
+	}
+	for file, content := range files {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(file), []byte(content), 0644))
+	}
+
+	found, err := routes.Scan(fs, config)
+	assert.NoError(t, err)
+
+	result, err := moveRoute(fs, config, found, *findRoute(found, config, "settings/profile"), "account/details")
+	assert.NoError(t, err)
+	assert.Equal(t, "/account/details", result.ToURL)
+	assert.Equal(t, []string{filepath.Join("components", "Nav.tsx")}, result.Rewritten)
+
+	page, _ := afero.ReadFile(fs, filepath.Join("app", "account", "details", "page.tsx"))
+	assert.Equal(t, "export default function DetailsPage() {}", string(page))
+	exists, _ := afero.Exists(fs, filepath.Join("app", "account", "details", "Avatar.tsx"))
+	assert.True(t, exists)
+
+	nav, _ := afero.ReadFile(fs, filepath.Join("components", "Nav.tsx"))
+	assert.Equal(t, `<Link href="/account/details">Profile</Link> <Link href="/settings">Settings</Link>`, string(nav))
+
+	_, err = addRedirect(fs, result.FromURL, result.ToURL)
+	assert.NoError(t, err)
+	nextConfig, _ := afero.ReadFile(fs, "next.config.js")
+	assert.Contains(t, string(nextConfig), "{ source: '/settings/profile', destination: '/account/details', permanent: true },")
+
+	// Renamed params are bound by position
+	_, err = addRedirect(fs, "/blog/[slug]", "/posts/[id]")
+	assert.NoError(t, err)
+	nextConfig, _ = afero.ReadFile(fs, "next.config.js")
+	assert.Contains(t, string(nextConfig), "{ source: '/blog/:slug', destination: '/posts/:slug', permanent: true },")
+
+	_, err = addRedirect(fs, "/docs/[...path]", "/guides/[...rest]")
+	assert.NoError(t, err)
+	nextConfig, _ = afero.ReadFile(fs, "next.config.js")
+	assert.Contains(t, string(nextConfig), "{ source: '/docs/:path*', destination: '/guides/:path*', permanent: true },")
+
+	_, err = addRedirect(fs, "/blog", "/blog/[slug]")
+	assert.Error(t, err)
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+)
+
+var nextConfigFiles = []string{"next.config.js", "next.config.mjs", "next.config.ts"}
+
+// Openings of the exported config object the redirects() function can be inserted into.
+var nextConfigOpenings = []string{"const nextConfig = {", "const nextConfig: NextConfig = {", "module.exports = {", "export default {"}
+
+// redirectSource converts a route URL to the path syntax used by next.config redirects ("/blog/:slug"),
+// returning the params it declares in order.
+func redirectSource(url string) (string, []string) {
+	var params []string
+	parts := routes.SplitURL(url)
+	for i, raw := range parts {
+		seg, err := routes.ParseSegment(raw)
+		if err != nil || !seg.Dynamic {
+			continue
+		}
+		if seg.CatchAll {
+			parts[i] = ":" + seg.Name + "*"
+		} else {
+			parts[i] = ":" + seg.Name
+		}
+		params = append(params, parts[i])
+	}
+	return "/" + strings.Join(parts, "/"), params
+}
+
+// redirectDestination converts a route URL to a redirect destination, binding its dynamic segments
+// to the source params by position like rewriteHref does ("/posts/[id]" -> "/posts/:slug").
+func redirectDestination(url string, params []string) (string, error) {
+	parts := routes.SplitURL(url)
+	for i, raw := range parts {
+		seg, err := routes.ParseSegment(raw)
+		if err != nil || !seg.Dynamic {
+			continue
+		}
+		if len(params) == 0 {
+			return "", fmt.Errorf("segment '%s' of %s has no matching param in the old URL", raw, url)
+		}
+		parts[i], params = params[0], params[1:]
+	}
+	return "/" + strings.Join(parts, "/"), nil
+}
+
+// redirectEntry renders a permanent redirect object for next.config.
+func redirectEntry(fromURL, toURL string) (string, error) {
+	source, params := redirectSource(fromURL)
+	destination, err := redirectDestination(toURL, params)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("{ source: '%s', destination: '%s', permanent: true },", source, destination), nil
+}
+
+// addRedirect inserts a permanent redirect into the project's next.config, returning the file it edited.
+func addRedirect(fs afero.Fs, fromURL, toURL string) (string, error) {
+	entry, err := redirectEntry(fromURL, toURL)
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range nextConfigFiles {
+		data, err := afero.ReadFile(fs, file)
+		if err != nil {
+			continue
+		}
+		content := string(data)
+
+		var updated string
+		if i := strings.Index(content, "redirects()"); i != -1 {
+			// Extend the existing redirects list
+			j := strings.Index(content[i:], "return [")
+			if j == -1 {
+				return "", fmt.Errorf("could not find the redirects list in '%s', please add this entry by hand:\n%s", file, entry)
+			}
+			at := i + j + len("return [")
+			updated = content[:at] + "\n      " + entry + content[at:]
+		} else {
+			for _, opening := range nextConfigOpenings {
+				if at := strings.Index(content, opening); at != -1 {
+					at += len(opening)
+					updated = content[:at] + "\n  async redirects() {\n    return [\n      " + entry + "\n    ];\n  }," + content[at:]
+					break
+				}
+			}
+		}
+		if updated == "" {
+			return "", fmt.Errorf("could not find the config object in '%s', please add this redirect by hand:\n%s", file, entry)
+		}
+
+		if err := afero.WriteFile(fs, file, []byte(updated), 0644); err != nil {
+			return "", fmt.Errorf("could not write file '%s': %w", file, err)
+		}
+		return file, nil
+	}
+	return "", fmt.Errorf("no next.config file found, please add this redirect by hand:\n%s", entry)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/spf13/afero"
+)
+
+// asyncParamsVersion is the first Next.js major version passing params and searchParams as Promises.
+const asyncParamsVersion = 15
+
+var majorVersionPattern = regexp.MustCompile(`\d+`)
+
+// nextVersion returns the Next.js version set in the config, or the one of the 'next' dependency
+// in package.json (e.g. "^15.0.3"). It is empty when neither is known.
+func nextVersion(fs afero.Fs, config *constants.Config) string {
+	if config.NextVersion != "" {
+		return config.NextVersion
+	}
+	data, err := afero.ReadFile(fs, "package.json")
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return ""
+	}
+	if version, ok := pkg.Dependencies["next"]; ok {
+		return version
+	}
+	return pkg.DevDependencies["next"]
+}
+
+// nextMajor returns the major version of a version or version range, or 0 for tags like "latest".
+func nextMajor(version string) int {
+	major, err := strconv.Atoi(majorVersionPattern.FindString(version))
+	if err != nil {
+		return 0
+	}
+	return major
+}
+
+// usesAsyncParams reports whether the Next.js version passes params as Promises.
+// Unknown versions are assumed to be recent.
+func usesAsyncParams(version string) bool {
+	major := nextMajor(version)
+	return major == 0 || major >= asyncParamsVersion
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/spf13/afero"
+)
+
+// PackData is the data pack templates are rendered with: the page data plus the pack variables.
+type PackData struct {
+	PageData
+	Vars map[string]any
+}
+
+// resolvePack finds the pack directory, either a path holding a pack.json or a pack name
+// under the packs directory (e.g. "ours/feature-page"), and loads its manifest.
+func resolvePack(fs afero.Fs, config *constants.Config, name string) (string, *constants.Pack, error) {
+	dir := filepath.Clean(name)
+	if exists, _ := afero.Exists(fs, filepath.Join(dir, constants.PackManifestFileName)); !exists {
+		dir = filepath.Join(config.PacksRoot(), dir)
+		if exists, _ := afero.Exists(fs, filepath.Join(dir, constants.PackManifestFileName)); !exists {
+			return "", nil, fmt.Errorf("no pack '%s' found in %s", name, config.PacksRoot())
+		}
+	}
+	pack, err := constants.LoadPack(fs, dir)
+	if err != nil {
+		return "", nil, err
+	}
+	return dir, pack, nil
+}
+
+// parseVarFlags turns the --var key=value flags into a map.
+func parseVarFlags(flags []string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, flag := range flags {
+		key, value, ok := strings.Cut(flag, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var '%s', expected key=value", flag)
+		}
+		vars[key] = value
+	}
+	return vars, nil
+}
+
+// packVars resolves the pack variables from the given values, asking for the missing ones
+// when ask is set. Empty answers fall back to the variable's default.
+func packVars(pack *constants.Pack, given map[string]string, ask func(constants.PackVariable) string) (map[string]any, error) {
+	declared := make(map[string]bool)
+	for _, v := range pack.Variables {
+		declared[v.Name] = true
+	}
+	for name := range given {
+		if !declared[name] {
+			return nil, fmt.Errorf("unknown variable '%s'", name)
+		}
+	}
+
+	vars := make(map[string]any)
+	for _, v := range pack.Variables {
+		raw, ok := given[v.Name]
+		if !ok && ask != nil {
+			raw = ask(v)
+		}
+		if raw == "" {
+			if v.Default == nil {
+				return nil, fmt.Errorf("variable '%s' is required", v.Name)
+			}
+			vars[v.Name] = v.Default
+			continue
+		}
+		value, err := v.Parse(raw)
+		if err != nil {
+			return nil, err
+		}
+		vars[v.Name] = value
+	}
+	return vars, nil
+}
+
+// promptPackVar asks for a variable on w and reads the answer from r, e.g. "Entity name [item]: ".
+func promptPackVar(r *bufio.Reader, w io.Writer) func(constants.PackVariable) string {
+	return func(v constants.PackVariable) string {
+		label := v.Prompt
+		if label == "" {
+			label = v.Name
+		}
+		if len(v.Choices) > 0 {
+			label += " (" + strings.Join(v.Choices, "/") + ")"
+		} else if v.Type == "bool" {
+			label += " (true/false)"
+		}
+		if v.Default != nil {
+			label += fmt.Sprintf(" [%v]", v.Default)
+		}
+		fmt.Fprintf(w, "%s: ", label)
+		answer, _ := r.ReadString('\n')
+		return strings.TrimSpace(answer)
+	}
+}
+
+// renderPack renders every file of the pack whose condition holds into the route directory.
+func renderPack(fs afero.Fs, dir string, pack *constants.Pack, routeDir string, data PackData) ([]GeneratedFile, error) {
+	var files []GeneratedFile
+	for _, file := range pack.Files {
+		if file.If != "" {
+			ok, err := renderTemplate(file.Template+" condition", "{{ if "+file.If+" }}true{{ end }}", data)
+			if err != nil {
+				return nil, err
+			}
+			if ok != "true" {
+				continue
+			}
+		}
+
+		path, err := renderTemplate(file.Template+" path", file.Path, data)
+		if err != nil {
+			return nil, err
+		}
+		// Variables end up in the path, so it is only checked once rendered
+		path, err = constants.CleanPackPath(path)
+		if err != nil {
+			return nil, err
+		}
+		text, err := afero.ReadFile(fs, filepath.Join(dir, file.Template))
+		if err != nil {
+			return nil, fmt.Errorf("error reading template file: %w", err)
+		}
+		content, err := renderTemplate(file.Template, string(text), data)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, GeneratedFile{Path: filepath.Join(routeDir, path), Content: content})
+	}
+	return files, nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bufio"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPack(t *testing.T) {
+	fs := useTemplateFs(t)
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: constants.Function}
+
+	dir := filepath.Join(".nextjs_routing_helper", "packs", "ours", "feature-page")
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "pack.json"), []byte(`{
+  "name": "feature-page",
+  "variables": [
+    { "name": "entity", "prompt": "Entity name" },
+    { "name": "layout", "choices": ["list", "grid"], "default": "list" },
+    { "name": "withForm", "type": "bool", "default": false },
+    { "name": "pageSize", "type": "number", "default": 20 }
+  ],
+  "files": [
+    { "template": "page.tsx.tmpl", "path": "page.tsx" },
+    { "template": "form.tsx.tmpl", "path": "_components/{{ pascal .Vars.entity }}Form.tsx", "if": ".Vars.withForm" }
+  ]
+}`), 0644))
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "page.tsx.tmpl"), []byte(`// {{ .URL }} lists {{ pluralize .Vars.entity }} as a {{ .Vars.layout }}, {{ .Vars.pageSize }} per page
+export default function {{ .ComponentName }}() {}`), 0644))
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "form.tsx.tmpl"), []byte(`export function {{ pascal .Vars.entity }}Form() {}`), 0644))
+
+	packDir, pack, err := resolvePack(fs, config, "ours/feature-page")
+	assert.NoError(t, err)
+	assert.Equal(t, dir, packDir)
+	_, _, err = resolvePack(fs, config, "ours/missing")
+	assert.Error(t, err)
+
+	// Missing variables are asked for, empty answers keep the default
+	answers := bufio.NewReader(strings.NewReader("product\n\n"))
+	var prompts strings.Builder
+	vars, err := packVars(pack, map[string]string{"withForm": "true"}, promptPackVar(answers, &prompts))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"entity": "product", "layout": "list", "withForm": true, "pageSize": float64(20)}, vars)
+	assert.Equal(t, "Entity name: layout (list/grid) [list]: pageSize [20]: ", prompts.String())
+
+	_, err = packVars(pack, map[string]string{"entity": "product", "layout": "table"}, nil)
+	assert.ErrorContains(t, err, "invalid value 'table' for variable 'layout'")
+	_, err = packVars(pack, map[string]string{"color": "red"}, nil)
+	assert.ErrorContains(t, err, "unknown variable 'color'")
+	_, err = packVars(pack, nil, nil)
+	assert.ErrorContains(t, err, "variable 'entity' is required")
+
+	routeDir := filepath.Join("app", "shop", "[category]")
+	data := PackData{PageData: newPageData("shop/[category]", "CategoryPage", config, false), Vars: vars}
+	files, err := renderPack(fs, packDir, pack, routeDir, data)
+	assert.NoError(t, err)
+	assert.Equal(t, []GeneratedFile{
+		{Path: filepath.Join(routeDir, "page.tsx"), Content: "// /shop/[category] lists products as a list, 20 per page\nexport default function CategoryPage() {}"},
+		{Path: filepath.Join(routeDir, "_components", "ProductForm.tsx"), Content: "export function ProductForm() {}"},
+	}, files)
+
+	data.Vars["withForm"] = false
+	files, err = renderPack(fs, packDir, pack, routeDir, data)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	// Variables can't move a file out of the route directory
+	pack.Files[0].Path = "{{ .Vars.entity }}.tsx"
+	data.Vars["entity"] = "../../../lib/product"
+	_, err = renderPack(fs, packDir, pack, routeDir, data)
+	assert.ErrorContains(t, err, "must stay inside the route directory")
+
+	// A mistyped variable fails instead of rendering "<no value>"
+	pack.Files[0].Path = "{{ .Vars.entiy }}.tsx"
+	data.Vars["entity"] = "product"
+	_, err = renderPack(fs, packDir, pack, routeDir, data)
+	assert.ErrorContains(t, err, `map has no entry for key "entiy"`)
+}
+
+func TestLoadPackValidation(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for manifest, message := range map[string]string{
+		`{"files": []}`: "no files declared",
+		`{"files": [{"template": "a.tmpl", "path": "a.tsx"}], "variables": [{"name": "x", "type": "date"}]}`:                   "invalid type 'date'",
+		`{"files": [{"template": "a.tmpl", "path": "a.tsx"}], "variables": [{"name": "x", "type": "bool", "choices": ["a"]}]}`: "only supported for strings",
+		`{"files": [{"template": "a.tmpl", "path": "a.tsx"}], "variables": [{"name": "x", "choices": ["a"], "default": "b"}]}`: "invalid default",
+		`{"files": [{"template": "a.tmpl", "path": "a.tsx"}], "variables": [{"name": "x"}, {"name": "x"}]}`:                    "duplicate variable 'x'",
+		`{"files": [{"template": "a.tmpl", "path": "../../a.tsx"}]}`:                                                           "must stay inside the route directory",
+		`{"files": [{"template": "a.tmpl", "path": "/tmp/a.tsx"}]}`:                                                            "must stay inside the route directory",
+	} {
+		assert.NoError(t, afero.WriteFile(fs, "pack/pack.json", []byte(manifest), 0644))
+		_, err := constants.LoadPack(fs, "pack")
+		assert.ErrorContains(t, err, message, manifest)
+	}
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var defaultExportPattern = regexp.MustCompile(`(?m)^\s*export\s+default\b`)
+
+var restyleCmd = &cobra.Command{
+	Use:   "restyle [paths] --flag",
+	Short: "Converts the component style of existing pages.",
+	Long: `Rewrites default-exported components between 'export default function X()' and
+'const X = () => {}; export default X;', keeping props, generics and bodies.
+- Paths can be files or directories and default to the routes directory.
+- The componentStyle of the config is updated so new pages follow the same style.
+- Files that cannot be transformed safely (e.g. 'React.FC' annotations, wrapped exports) are reported and left untouched.
+`,
+	Run: func(cmd *cobra.Command, args []string) {
+		toFlag, _ := cmd.Flags().GetString("to")
+		dryRunFlag, _ := cmd.Flags().GetBool("dry-run")
+
+		to := constants.ComponentStyleType(toFlag)
+		if to != constants.Function && to != constants.Const {
+			fmt.Fprintf(os.Stderr, "Invalid style '%s', expected '%s' or '%s'\n", toFlag, constants.Function, constants.Const)
+			os.Exit(1)
+		}
+
+		config, err := constants.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+			os.Exit(1)
+		}
+		if len(args) == 0 {
+			args = []string{config.RoutesDir()}
+		}
+
+		var results []RestyleResult
+		for _, path := range args {
+			found, err := restyleFiles(AppFs, path, to, dryRunFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error restyling %s:\n%v\n", path, err)
+				os.Exit(1)
+			}
+			results = append(results, found...)
+		}
+
+		failed := 0
+		for _, result := range results {
+			if result.Err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "Skipped %s: %v\n", result.File, result.Err)
+				continue
+			}
+			fmt.Printf("Restyled %s\n", result.File)
+		}
+		if dryRunFlag {
+			return
+		}
+
+		if config.ComponentStyle != to {
+			config.ComponentStyle = to
+			if err := constants.WriteConfig(AppFs, *config); err != nil {
+				fmt.Fprintf(os.Stderr, "Error updating configuration:\n%v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Updated componentStyle to '%s' in %s\n", to, constants.ConfigFileName)
+		}
+		if failed > 0 {
+			fmt.Fprintf(os.Stderr, "%d file(s) could not be restyled safely.\n", failed)
+			os.Exit(1)
+		}
+	},
+}
+
+// RestyleResult is a file that was restyled, or could not be when Err is set.
+type RestyleResult struct {
+	File string
+	Err  error
+}
+
+// restyleFiles converts the default-exported components of the file or the source files under the directory.
+// Files without a default export or already in the target style are left out of the results.
+func restyleFiles(fs afero.Fs, path string, to constants.ComponentStyleType, dryRun bool) ([]RestyleResult, error) {
+	var results []RestyleResult
+	restyle := func(file string) error {
+		// Components live in .tsx, .jsx and .js files, .ts files hold route handlers and helpers
+		if filepath.Ext(file) == ".ts" {
+			return nil
+		}
+		content, err := afero.ReadFile(fs, file)
+		if err != nil {
+			return err
+		}
+		if !defaultExportPattern.Match(content) || routes.DetectComponentStyle(content) == to {
+			return nil
+		}
+		updated, err := routes.Restyle(string(content), to, filepath.Ext(file) == ".tsx")
+		if err != nil {
+			results = append(results, RestyleResult{File: file, Err: err})
+			return nil
+		}
+		if !dryRun {
+			if err := afero.WriteFile(fs, file, []byte(updated), 0644); err != nil {
+				return err
+			}
+		}
+		results = append(results, RestyleResult{File: file})
+		return nil
+	}
+
+	info, err := fs.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return results, restyle(path)
+	}
+	return results, routes.WalkSources(fs, path, restyle)
+}
+
+func init() {
+	rootCmd.AddCommand(restyleCmd)
+	restyleCmd.Flags().String("to", "", "Component style to convert to (function or const)")
+	restyleCmd.Flags().Bool("dry-run", false, "Only report the files that would be restyled")
+	restyleCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestyleFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	files := map[string]string{
+		"app/page.tsx":           "export default function Home() {\n  return <h1>Home</h1>;\n}\n",
+		"app/about/page.tsx":     "const About = () => {\n  return <h1>About</h1>;\n};\n\nexport default About;\n",
+		"app/blog/page.tsx":      "export default memo(Blog);\n",
+		"app/api/users/route.ts": "export async function GET() {}\n",
+	}
+	for path, content := range files {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(path), []byte(content), 0644))
+	}
+
+	results, err := restyleFiles(fs, "app", constants.Const, false)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, filepath.Join("app", "blog", "page.tsx"), results[0].File)
+	assert.Error(t, results[0].Err)
+	assert.Equal(t, filepath.Join("app", "page.tsx"), results[1].File)
+	assert.NoError(t, results[1].Err)
+
+	home, _ := afero.ReadFile(fs, filepath.Join("app", "page.tsx"))
+	assert.Equal(t, "const Home = () => {\n  return <h1>Home</h1>;\n};\n\nexport default Home;\n", string(home))
+
+	results, err = restyleFiles(fs, filepath.Join("app", "about", "page.tsx"), constants.Function, true)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	about, _ := afero.ReadFile(fs, filepath.Join("app", "about", "page.tsx"))
+	assert.Equal(t, files["app/about/page.tsx"], string(about))
+}
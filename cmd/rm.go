@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var rmCmd = &cobra.Command{
+	Use:   "rm [page-name] --flag",
+	Short: "Removes a page from your Next.js project.",
+	Long: `Removes a page and its Next.js special files (layout, loading, error, ...).
+- Page name is the same path used with 'add' (e.g., 'blog/[slug]') or the route URL.
+- Directories left empty are pruned.
+- Directories still holding other files are kept unless --force is given.
+- Child routes in subdirectories are always kept.
+- References to the removed URL are listed so no dead links are left behind.
+`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		forceFlag, _ := cmd.Flags().GetBool("force")
+
+		config, err := constants.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+			fmt.Fprintln(os.Stderr, "Please run 'nextjs-routing-helper-cli init' first.")
+			os.Exit(1)
+		}
+
+		found, err := routes.Scan(AppFs, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning routes:\n%v\n", err)
+			os.Exit(1)
+		}
+		links, err := routes.FindLinks(AppFs, ".")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning links:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		for _, pageNameInput := range args {
+			route := findRoute(found, config, pageNameInput)
+			if route == nil {
+				fmt.Fprintf(os.Stderr, "Error: no page found for '%s'\n", pageNameInput)
+				os.Exit(1)
+			}
+
+			removed, err := removeRoute(AppFs, config, *route, forceFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error removing page:\n%v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Removed %s:\n", route.URL)
+			for _, file := range removed {
+				fmt.Printf("- %s\n", file)
+			}
+
+			if refs := linksTo(found, links, *route); len(refs) > 0 {
+				fmt.Printf("Found %d reference(s) to %s:\n", len(refs), route.URL)
+				for _, link := range refs {
+					fmt.Printf("  %s:%d  %s\n", link.File, link.Line, link.Href)
+				}
+			}
+		}
+	},
+}
+
+// findRoute looks up a route either by its location under the routes directory or by its URL.
+func findRoute(found []routes.Route, config *constants.Config, pageNameInput string) *routes.Route {
+	input := strings.Trim(filepath.ToSlash(pageNameInput), "/")
+	location := filepath.Join(config.RoutesDir(), filepath.FromSlash(input))
+
+	for i, route := range found {
+		if config.Router == constants.AppRouter {
+			if route.Dir == location {
+				return &found[i]
+			}
+			continue
+		}
+		withoutExt := strings.TrimSuffix(route.File, filepath.Ext(route.File))
+		if withoutExt == location || withoutExt == filepath.Join(location, "index") {
+			return &found[i]
+		}
+	}
+	for i, route := range found {
+		if route.URL == "/"+input {
+			return &found[i]
+		}
+	}
+	return nil
+}
+
+// ownsDir reports whether the route's directory belongs to it alone (app router segments and pages router index files).
+func ownsDir(config *constants.Config, route routes.Route) bool {
+	if config.Router == constants.AppRouter {
+		return true
+	}
+	name := filepath.Base(route.File)
+	return strings.TrimSuffix(name, filepath.Ext(name)) == "index" && route.Dir != config.RoutesDir()
+}
+
+// generatedFiles returns the files of a route this tool knows how to create.
+func generatedFiles(route routes.Route) []string {
+	files := []string{route.File}
+	for _, file := range route.Special {
+		files = append(files, file)
+	}
+	sort.Strings(files[1:])
+	return files
+}
+
+// removeRoute deletes a route's files and prunes the directories left empty.
+// Subdirectories holding child routes are never touched, and neither are the special files wrapping them.
+func removeRoute(fs afero.Fs, config *constants.Config, route routes.Route, force bool) ([]string, error) {
+	files := generatedFiles(route)
+
+	var others []string
+	if ownsDir(config, route) {
+		found, err := routes.Scan(fs, config)
+		if err != nil {
+			return nil, err
+		}
+		// The layouts, templates and boundaries of the root and of parent routes still wrap their children
+		if route.Dir == config.RoutesDir() || hasChildRoutes(found, route) {
+			files = []string{route.File}
+		} else {
+			others, err = foreignEntries(fs, route.Dir, files, found)
+			if err != nil {
+				return nil, err
+			}
+			if len(others) > 0 && !force {
+				return nil, fmt.Errorf("directory '%s' still contains files not created by this tool:\n- %s\nUse --force to delete them anyway",
+					route.Dir, strings.Join(others, "\n- "))
+			}
+		}
+	}
+
+	for _, file := range files {
+		if err := fs.Remove(file); err != nil {
+			return nil, fmt.Errorf("could not remove file '%s': %w", file, err)
+		}
+	}
+	for _, other := range others {
+		if err := fs.RemoveAll(other); err != nil {
+			return nil, fmt.Errorf("could not remove '%s': %w", other, err)
+		}
+	}
+	return append(files, others...), pruneEmptyDirs(fs, route.Dir, config.RoutesDir())
+}
+
+// foreignEntries lists the entries of dir that are neither among the given generated files
+// nor subdirectories holding other routes.
+func foreignEntries(fs afero.Fs, dir string, generated []string, found []routes.Route) ([]string, error) {
+	entries, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read directory '%s': %w", dir, err)
+	}
+	known := make(map[string]bool)
+	for _, file := range generated {
+		known[file] = true
+	}
+	var others []string
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if known[path] || entry.IsDir() && holdsRoutes(found, path) {
+			continue
+		}
+		others = append(others, path)
+	}
+	return others, nil
+}
+
+// hasChildRoutes reports whether other routes live below the route's directory.
+func hasChildRoutes(found []routes.Route, route routes.Route) bool {
+	for _, other := range found {
+		if strings.HasPrefix(other.Dir, route.Dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// holdsRoutes reports whether any of the routes lives in dir or below it.
+func holdsRoutes(found []routes.Route, dir string) bool {
+	for _, route := range found {
+		if route.Dir == dir || strings.HasPrefix(route.Dir, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneEmptyDirs removes dir and its parents while they are empty, stopping at (and keeping) the root.
+func pruneEmptyDirs(fs afero.Fs, dir string, root string) error {
+	for dir != root && strings.HasPrefix(dir, root+string(filepath.Separator)) {
+		empty, err := afero.IsEmpty(fs, dir)
+		if err != nil || !empty {
+			return nil
+		}
+		if err := fs.Remove(dir); err != nil {
+			return fmt.Errorf("could not remove directory '%s': %w", dir, err)
+		}
+		dir = filepath.Dir(dir)
+	}
+	return nil
+}
+
+// linksTo returns the links that resolve to the given route.
+func linksTo(found []routes.Route, links []routes.Link, route routes.Route) []routes.Link {
+	var refs []routes.Link
+	for _, link := range links {
+		if target := routes.Resolve(found, link.Href); target != nil && target.File == route.File {
+			refs = append(refs, link)
+		}
+	}
+	return refs
+}
+
+func init() {
+	rootCmd.AddCommand(rmCmd)
+	rmCmd.Flags().Bool("force", false, "Also delete files in the page directory that were not created by this tool (child routes are kept)")
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveRoute(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	config := &constants.Config{Router: constants.AppRouter}
+	for _, file := range []string{
+		"app/layout.tsx",
+		"app/error.tsx",
+		"app/page.tsx",
+		"app/about/page.tsx",
+		"app/about/Team.tsx",
+		"app/about/_components/Banner.tsx",
+		"app/blog/[slug]/page.tsx",
+		"app/blog/[slug]/loading.tsx",
+		"app/shop/page.tsx",
+		"app/shop/layout.tsx",
+		"app/shop/error.tsx",
+		"app/shop/ProductCard.tsx",
+		"app/shop/cart/page.tsx",
+		"app/shop/cart/loading.tsx",
+	} {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(file), []byte(""), 0644))
+	}
+
+	found, err := routes.Scan(fs, config)
+	assert.NoError(t, err)
+
+	// Removing a page prunes its now empty parents
+	removed, err := removeRoute(fs, config, *findRoute(found, config, "blog/[slug]"), false)
+	assert.NoError(t, err)
+	assert.Len(t, removed, 2)
+	exists, _ := afero.DirExists(fs, filepath.Join("app", "blog"))
+	assert.False(t, exists)
+
+	// Directories with other files are kept unless forced
+	about := *findRoute(found, config, "/about")
+	_, err = removeRoute(fs, config, about, false)
+	assert.Error(t, err)
+	exists, _ = afero.Exists(fs, filepath.Join("app", "about", "page.tsx"))
+	assert.True(t, exists)
+
+	removed, err = removeRoute(fs, config, about, true)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join("app", "about", "page.tsx"),
+		filepath.Join("app", "about", "Team.tsx"),
+		filepath.Join("app", "about", "_components"),
+	}, removed)
+	exists, _ = afero.DirExists(fs, filepath.Join("app", "about"))
+	assert.False(t, exists)
+
+	// A page with child routes loses only its page file, the special files still wrap the children
+	removed, err = removeRoute(fs, config, *findRoute(found, config, "/shop"), false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("app", "shop", "page.tsx")}, removed)
+	for _, file := range []string{"layout.tsx", "error.tsx", "ProductCard.tsx", "cart/page.tsx", "cart/loading.tsx"} {
+		exists, _ = afero.Exists(fs, filepath.Join("app", "shop", filepath.FromSlash(file)))
+		assert.True(t, exists, file)
+	}
+
+	// The root layout is never removed
+	found, err = routes.Scan(fs, config)
+	assert.NoError(t, err)
+	removed, err = removeRoute(fs, config, *findRoute(found, config, "/"), false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("app", "page.tsx")}, removed)
+	exists, _ = afero.Exists(fs, filepath.Join("app", "layout.tsx"))
+	assert.True(t, exists)
+
+	// Removing the last child leaves the parent's special files to the user
+	found, err = routes.Scan(fs, config)
+	assert.NoError(t, err)
+	_, err = removeRoute(fs, config, *findRoute(found, config, "shop/cart"), false)
+	assert.NoError(t, err)
+	exists, _ = afero.DirExists(fs, filepath.Join("app", "shop", "cart"))
+	assert.False(t, exists)
+	exists, _ = afero.Exists(fs, filepath.Join("app", "shop", "layout.tsx"))
+	assert.True(t, exists)
+}
+
+func TestViewDeleteKeepsChildRoutes(t *testing.T) {
+	fs := useTemplateFs(t)
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript}
+	for _, file := range []string{"app/shop/page.tsx", "app/shop/cart/page.tsx"} {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(file), []byte(""), 0644))
+	}
+
+	assert.NoError(t, viewActions(config).Delete("shop"))
+	exists, _ := afero.Exists(fs, filepath.Join("app", "shop", "page.tsx"))
+	assert.False(t, exists)
+	exists, _ = afero.Exists(fs, filepath.Join("app", "shop", "cart", "page.tsx"))
+	assert.True(t, exists)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 
+	"github.com/common-nighthawk/go-figure"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 )
@@ -25,6 +26,8 @@ var AppFs afero.Fs = afero.NewOsFs()
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
+	// The banner goes to stderr so machine readable output (e.g. --json) stays parseable
+	figure.Write(os.Stderr, figure.NewFigure("Next.js Routing Helper", "rectangles", true))
 	err := rootCmd.Execute()
 	if err != nil {
 		os.Exit(1)
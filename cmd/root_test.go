@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// executeCommand runs the CLI with the given arguments in dir and returns what it printed to stdout.
+func executeCommand(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	t.Chdir(dir)
+
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+	// Only stdout is read back, stderr (the banner and errors) is discarded
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	assert.NoError(t, err)
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = w, devNull
+	t.Cleanup(func() {
+		os.Stdout, os.Stderr = stdout, stderr
+		devNull.Close()
+	})
+
+	out := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		out <- string(data)
+	}()
+	rootCmd.SetArgs(args)
+	Execute()
+	w.Close()
+	return <-out
+}
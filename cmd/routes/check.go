@@ -0,0 +1,118 @@
+package routes
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Issue is a problem found in the route tree, tied to the file that causes it.
+type Issue struct {
+	File    string `json:"file"`
+	Message string `json:"message"`
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.File, i.Message)
+}
+
+// Check validates the scanned routes against the rules Next.js enforces at build time.
+func Check(found []Route) []Issue {
+	var issues []Issue
+
+	// Segment syntax and parameter names
+	for _, route := range found {
+		segments := SplitURL(route.URL)
+		seen := make(map[string]bool)
+		for i, raw := range segments {
+			seg, err := ParseSegment(raw)
+			if err != nil {
+				issues = append(issues, Issue{File: route.File, Message: err.Error()})
+				continue
+			}
+			if !seg.Dynamic {
+				continue
+			}
+			if seg.CatchAll && i != len(segments)-1 {
+				issues = append(issues, Issue{File: route.File, Message: fmt.Sprintf("catch-all segment '%s' must be the last segment", raw)})
+			}
+			if seen[seg.Name] {
+				issues = append(issues, Issue{File: route.File, Message: fmt.Sprintf("parameter '%s' is used more than once in %s", seg.Name, route.URL)})
+			}
+			seen[seg.Name] = true
+		}
+	}
+
+	// Routes resolving to the same URL, parallel slots render alongside the page so they are compared per slot
+	byURL := make(map[string]Route)
+	for _, route := range found {
+		key := slotPath(route.Dir) + route.URL
+		other, exists := byURL[key]
+		if !exists {
+			byURL[key] = route
+			continue
+		}
+		// Both files are reported, so either of them being staged surfaces the conflict
+		if other.Dir == route.Dir && other.Kind != route.Kind {
+			issues = append(issues,
+				Issue{File: route.File, Message: fmt.Sprintf("page and route handler cannot share a segment (%s)", other.File)},
+				Issue{File: other.File, Message: fmt.Sprintf("page and route handler cannot share a segment (%s)", route.File)},
+			)
+		} else {
+			issues = append(issues,
+				Issue{File: route.File, Message: fmt.Sprintf("route %s is already defined by %s", route.URL, other.File)},
+				Issue{File: other.File, Message: fmt.Sprintf("route %s is also defined by %s", route.URL, route.File)},
+			)
+		}
+	}
+
+	// Sibling dynamic segments must use the same name
+	siblings := make(map[string]map[string]bool)
+	for _, route := range found {
+		segments := SplitURL(route.URL)
+		for i, raw := range segments {
+			if seg, err := ParseSegment(raw); err == nil && seg.Dynamic {
+				parent := "/" + strings.Join(segments[:i], "/")
+				if siblings[parent] == nil {
+					siblings[parent] = make(map[string]bool)
+				}
+				siblings[parent][raw] = true
+			}
+		}
+	}
+	for _, route := range found {
+		segments := SplitURL(route.URL)
+		for i, raw := range segments {
+			parent := "/" + strings.Join(segments[:i], "/")
+			names := siblings[parent]
+			if len(names) < 2 || !names[raw] {
+				continue
+			}
+			var others []string
+			for name := range names {
+				if name != raw {
+					others = append(others, name)
+				}
+			}
+			sort.Strings(others)
+			issues = append(issues, Issue{File: route.File, Message: fmt.Sprintf("dynamic segment %s conflicts with %s under %s", raw, strings.Join(others, ", "), parent)})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].File < issues[j].File
+	})
+	return issues
+}
+
+// slotPath returns the parallel route slots the directory is nested in, e.g. "@modal" for "app/@modal/(.)photo".
+func slotPath(dir string) string {
+	var slots []string
+	for _, part := range strings.Split(filepath.ToSlash(dir), "/") {
+		if IsSlot(part) {
+			slots = append(slots, part)
+		}
+	}
+	return strings.Join(slots, "/")
+}
@@ -0,0 +1,130 @@
+package routes
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ServerSafeHooks are the React hooks that are also available in server components.
+var ServerSafeHooks = []string{"use", "useId", "useMemo", "useCallback", "useDebugValue"}
+
+var (
+	// Hooks of React, React DOM and next/navigation that only run in client components
+	clientHookPattern = regexp.MustCompile(`\b(useState|useEffect|useLayoutEffect|useInsertionEffect|useReducer|useRef|useContext|useTransition|useDeferredValue|useImperativeHandle|useSyncExternalStore|useOptimistic|useActionState|useFormStatus|useRouter|usePathname|useSearchParams|useParams|useSelectedLayoutSegments?)\s*(?:<[^>]*>)?\(`)
+	// Any hook call, including custom ones
+	hookPattern         = regexp.MustCompile(`\b(use[A-Z]\w*)\s*(?:<[^>]*>)?\(`)
+	eventHandlerPattern = regexp.MustCompile(`\b(on[A-Z][A-Za-z]*)\s*=\s*\{`)
+	browserAPIPattern   = regexp.MustCompile(`\b(window|document|localStorage|sessionStorage|navigator)\s*\.`)
+	// Contexts can only be created and provided in client components
+	contextPattern = regexp.MustCompile(`\b(createContext)\s*(?:<[^>]*>)?\(|<(\w+\.Provider)\b`)
+	// export default async function, async function Page(, const Page = async (
+	asyncComponentPattern = regexp.MustCompile(`\bexport\s+default\s+async\b|\basync\s+function\s+[A-Z]\w*\s*[(<]|\bconst\s+[A-Z]\w*\s*(?::[^=]+)?=\s*async\b`)
+)
+
+// ClientFeatures returns the client-only hooks, event handlers, browser APIs and contexts used by the file,
+// in order of first use. Custom hooks are included when withCustomHooks is set.
+func ClientFeatures(content []byte, withCustomHooks bool) []string {
+	code := stripComments(string(content))
+	var features []string
+	seen := make(map[string]bool)
+	add := func(pattern *regexp.Regexp) {
+		for _, m := range pattern.FindAllStringSubmatch(code, -1) {
+			// The feature is the first group that matched
+			for _, name := range m[1:] {
+				if name == "" {
+					continue
+				}
+				if !seen[name] {
+					seen[name] = true
+					features = append(features, name)
+				}
+				break
+			}
+		}
+	}
+	add(clientHookPattern)
+	add(eventHandlerPattern)
+	add(browserAPIPattern)
+	add(contextPattern)
+	if withCustomHooks {
+		for _, m := range hookPattern.FindAllStringSubmatch(code, -1) {
+			if !seen[m[1]] && !isServerSafeHook(m[1]) {
+				seen[m[1]] = true
+				features = append(features, m[1])
+			}
+		}
+	}
+	return features
+}
+
+// HasAsyncComponent reports whether the file declares an async component.
+func HasAsyncComponent(content []byte) bool {
+	return asyncComponentPattern.MatchString(stripComments(string(content)))
+}
+
+func isServerSafeHook(name string) bool {
+	for _, hook := range ServerSafeHooks {
+		if name == hook {
+			return true
+		}
+	}
+	return false
+}
+
+// stripComments blanks out comments and single-line string literals so that
+// their content is not mistaken for code. Template literals are kept as they may hold expressions.
+func stripComments(content string) string {
+	var b strings.Builder
+	b.Grow(len(content))
+	for i := 0; i < len(content); {
+		switch {
+		case strings.HasPrefix(content[i:], "//"):
+			end := strings.IndexByte(content[i:], '\n')
+			if end == -1 {
+				end = len(content) - i
+			}
+			b.WriteString(strings.Repeat(" ", end))
+			i += end
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end == -1 {
+				end = len(content) - i - 2
+			} else {
+				end += 2
+			}
+			b.WriteString(blank(content[i : i+2+end]))
+			i += 2 + end
+		case content[i] == '\'' || content[i] == '"':
+			// Apostrophes in JSX text never span lines, so a string ends at the line break at the latest
+			end := i + 1
+			for end < len(content) && content[end] != content[i] && content[end] != '\n' {
+				if content[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end < len(content) && content[end] == content[i] {
+				end++
+			}
+			if end > len(content) {
+				end = len(content)
+			}
+			b.WriteString(blank(content[i:end]))
+			i = end
+		default:
+			b.WriteByte(content[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+// blank replaces every character but line breaks with spaces.
+func blank(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\n' {
+			return r
+		}
+		return ' '
+	}, s)
+}
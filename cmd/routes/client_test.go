@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientFeatures(t *testing.T) {
+	content := `// useEffect(() => {}) is not called here
+import { useState } from 'react';
+import { useCart } from './cart';
+
+export default function Cart() {
+  const [open, setOpen] = useState<boolean>(false);
+  const cart = useCart();
+  return <button onClick={() => setOpen(!open)}>Don't use window.alert</button>;
+}`
+	assert.Equal(t, []string{"useState", "onClick"}, ClientFeatures([]byte(content), false))
+	assert.Equal(t, []string{"useState", "onClick", "useCart"}, ClientFeatures([]byte(content), true))
+	assert.False(t, HasAsyncComponent([]byte(content)))
+
+	provider := `'use client';
+
+import { createContext } from 'react';
+
+export const ThemeContext = createContext<string>('light');
+
+export default function ThemeProvider({ children }: { children: React.ReactNode }) {
+  return <ThemeContext.Provider value="dark">{children}</ThemeContext.Provider>;
+}`
+	assert.Equal(t, []string{"createContext", "ThemeContext.Provider"}, ClientFeatures([]byte(provider), false))
+
+	assert.True(t, HasAsyncComponent([]byte("export default async function Page() {}")))
+	assert.True(t, HasAsyncComponent([]byte("const Page = async () => {}")))
+	assert.False(t, HasAsyncComponent([]byte("async function load() {}")))
+}
+
+func TestAddAndRemoveDirective(t *testing.T) {
+	header := "/**\n * Copyright\n */\nimport x from 'x';\n"
+	withClient := "/**\n * Copyright\n */\n'use client';\n\nimport x from 'x';\n"
+	assert.Equal(t, withClient, AddDirective(header, "use client"))
+	assert.Equal(t, withClient, AddDirective(withClient, "use client"))
+	assert.Equal(t, "/**\n * Copyright\n */\nimport x from 'x';\n", RemoveDirective(withClient, "use client"))
+
+	assert.Equal(t, "'use client';\n'use strict';\nx();", AddDirective("'use strict';\nx();", "use client"))
+	assert.Equal(t, "'use strict';\nx();", RemoveDirective("'use client';\n'use strict';\nx();", "use client"))
+	assert.Equal(t, "import x from 'x';", RemoveDirective("\"use client\"\n\nimport x from 'x';", "use client"))
+}
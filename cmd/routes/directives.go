@@ -0,0 +1,131 @@
+package routes
+
+import (
+	"strings"
+)
+
+// Directive positions within a file's prologue.
+type directive struct {
+	Value string
+	Start int
+	End   int
+}
+
+// prologue returns the directives ('use client', 'use strict', ...) at the top of a file,
+// skipping leading whitespace and comments, along with the offset where the code begins.
+func prologue(content string) ([]directive, int) {
+	var found []directive
+	i := 0
+	for {
+		i = skipTrivia(content, i)
+		if i >= len(content) || (content[i] != '\'' && content[i] != '"') {
+			return found, i
+		}
+		quote := content[i]
+		end := strings.IndexByte(content[i+1:], quote)
+		if end == -1 {
+			return found, i
+		}
+		value := content[i+1 : i+1+end]
+		stop := i + end + 2
+		// A directive is a lone string statement, not the start of an expression
+		rest := skipSpaces(content, stop)
+		if rest < len(content) && content[rest] == ';' {
+			stop = rest + 1
+		} else if rest < len(content) && content[rest] != '\n' && content[rest] != '\r' && !strings.HasPrefix(content[rest:], "//") {
+			return found, i
+		}
+		found = append(found, directive{Value: value, Start: i, End: stop})
+		i = stop
+	}
+}
+
+func skipSpaces(content string, i int) int {
+	for i < len(content) && (content[i] == ' ' || content[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+// skipTrivia skips whitespace and comments starting at i.
+func skipTrivia(content string, i int) int {
+	for i < len(content) {
+		switch {
+		case content[i] == ' ' || content[i] == '\t' || content[i] == '\n' || content[i] == '\r':
+			i++
+		case strings.HasPrefix(content[i:], "//"):
+			end := strings.IndexByte(content[i:], '\n')
+			if end == -1 {
+				return len(content)
+			}
+			i += end + 1
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end == -1 {
+				return len(content)
+			}
+			i += end + 4
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// HasDirective reports whether the file starts with the given directive (e.g. "use client").
+func HasDirective(content []byte, value string) bool {
+	found, _ := prologue(string(content))
+	for _, d := range found {
+		if d.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// IsClientComponent reports whether the file is marked with the 'use client' directive.
+func IsClientComponent(content []byte) bool {
+	return HasDirective(content, "use client")
+}
+
+// AddDirective inserts the directive at the top of the file, after any header comments
+// and next to existing directives. The content is returned unchanged when it is already present.
+func AddDirective(content, value string) string {
+	found, start := prologue(content)
+	for _, d := range found {
+		if d.Value == value {
+			return content
+		}
+	}
+	line := "'" + value + "';\n"
+	if len(found) > 0 {
+		return content[:found[0].Start] + line + content[found[0].Start:]
+	}
+	return content[:start] + line + "\n" + content[start:]
+}
+
+// RemoveDirective removes the directive from the file's prologue along with its line break,
+// keeping header comments and other directives intact.
+func RemoveDirective(content, value string) string {
+	found, _ := prologue(content)
+	for i := len(found) - 1; i >= 0; i-- {
+		d := found[i]
+		if d.Value != value {
+			continue
+		}
+		end := skipSpaces(content, d.End)
+		if strings.HasPrefix(content[end:], "\r\n") {
+			end += 2
+		} else if end < len(content) && content[end] == '\n' {
+			end++
+		}
+		// The blank lines separating the prologue from the code go along with it
+		if d.Start == 0 || len(found) == 1 {
+			for end < len(content) && (content[end] == '\n' || content[end] == '\r') {
+				end++
+			}
+		}
+		content = content[:d.Start] + content[end:]
+	}
+	return content
+}
@@ -0,0 +1,121 @@
+package routes
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/spf13/afero"
+)
+
+// SegmentConfigKeys are the route segment config exports Next.js reads from pages, layouts and route handlers.
+var SegmentConfigKeys = []string{"dynamic", "dynamicParams", "revalidate", "fetchCache", "runtime", "preferredRegion", "maxDuration"}
+
+var (
+	segmentConfigPattern    = regexp.MustCompile(`(?m)^\s*export\s+const\s+(` + strings.Join(SegmentConfigKeys, "|") + `)\s*(?::[^=]+)?=\s*([^;\n]+)`)
+	staticMetadataPattern   = regexp.MustCompile(`(?m)^\s*export\s+const\s+metadata\b`)
+	generateMetadataPattern = regexp.MustCompile(`(?m)^\s*export\s+(?:(?:async\s+)?function\s+generateMetadata\b|const\s+generateMetadata\b)`)
+	staticParamsPattern     = regexp.MustCompile(`(?m)^\s*export\s+(?:(?:async\s+)?function\s+generateStaticParams\b|const\s+generateStaticParams\b)`)
+)
+
+// Details is what can be learned about a route by reading its files.
+type Details struct {
+	Client        bool              `json:"client"`
+	Metadata      string            `json:"metadata,omitempty"`
+	SegmentConfig map[string]string `json:"segmentConfig,omitempty"`
+	Layouts       []string          `json:"layouts,omitempty"`
+}
+
+// Inspect reads the route's file and ancestors to describe it.
+func Inspect(fs afero.Fs, config *constants.Config, route Route) (Details, error) {
+	content, err := afero.ReadFile(fs, route.File)
+	if err != nil {
+		return Details{}, err
+	}
+
+	details := Details{
+		Client:        IsClientComponent(content),
+		Metadata:      MetadataKind(content),
+		SegmentConfig: SegmentConfig(content),
+	}
+	if config.Router == constants.AppRouter {
+		details.Layouts = Chain(fs, config, route.Dir, "layout")
+	}
+	return details, nil
+}
+
+// SegmentConfig returns the route segment config exports of a file and their source values.
+func SegmentConfig(content []byte) map[string]string {
+	matches := segmentConfigPattern.FindAllSubmatch(content, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+	exports := make(map[string]string)
+	for _, m := range matches {
+		exports[string(m[1])] = strings.TrimSpace(string(m[2]))
+	}
+	return exports
+}
+
+// MetadataKind returns "static" for an exported metadata object, "generateMetadata" for the
+// async variant and "" when the file exports no metadata.
+func MetadataKind(content []byte) string {
+	switch {
+	case generateMetadataPattern.Match(content):
+		return "generateMetadata"
+	case staticMetadataPattern.Match(content):
+		return "static"
+	default:
+		return ""
+	}
+}
+
+// ServerOnlyExports returns the exports of a file that client components cannot have:
+// metadata, generateStaticParams and the route segment config.
+func ServerOnlyExports(content []byte) []string {
+	var exports []string
+	switch MetadataKind(content) {
+	case "generateMetadata":
+		exports = append(exports, "generateMetadata")
+	case "static":
+		exports = append(exports, "metadata")
+	}
+	if staticParamsPattern.Match(content) {
+		exports = append(exports, "generateStaticParams")
+	}
+	config := SegmentConfig(content)
+	for _, key := range SegmentConfigKeys {
+		if _, ok := config[key]; ok {
+			exports = append(exports, key)
+		}
+	}
+	return exports
+}
+
+// Ancestors returns the directories from the routes directory down to dir, both included.
+func Ancestors(config *constants.Config, dir string) []string {
+	base := config.RoutesDir()
+	dirs := []string{base}
+	rel, err := filepath.Rel(base, dir)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return dirs
+	}
+	current := base
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		current = filepath.Join(current, part)
+		dirs = append(dirs, current)
+	}
+	return dirs
+}
+
+// Chain returns the special files with the given name (e.g. "layout") wrapping dir, from the root down.
+func Chain(fs afero.Fs, config *constants.Config, dir string, name string) []string {
+	var files []string
+	for _, ancestor := range Ancestors(config, dir) {
+		if file := FindSource(fs, ancestor, name); file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspect(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	config := &constants.Config{Router: constants.AppRouter}
+	writeFiles(t, fs, "app/layout.tsx", "app/(blog)/layout.tsx", "app/(blog)/posts/[slug]/layout.tsx")
+	page := `// Post page
+'use client';
+
+export const revalidate = 60;
+export const dynamic: string = 'force-static';
+
+export async function generateMetadata({ params }) {}
+
+export default function Post() {}
+`
+	assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash("app/(blog)/posts/[slug]/page.tsx"), []byte(page), 0644))
+
+	found, err := Scan(fs, config)
+	assert.NoError(t, err)
+	details, err := Inspect(fs, config, found[0])
+	assert.NoError(t, err)
+
+	assert.Equal(t, Details{
+		Client:        true,
+		Metadata:      "generateMetadata",
+		SegmentConfig: map[string]string{"revalidate": "60", "dynamic": "'force-static'"},
+		Layouts: []string{
+			filepath.Join("app", "layout.tsx"),
+			filepath.Join("app", "(blog)", "layout.tsx"),
+			filepath.Join("app", "(blog)", "posts", "[slug]", "layout.tsx"),
+		},
+	}, details)
+}
+
+func TestServerOnlyExports(t *testing.T) {
+	content := []byte(`export const revalidate = 60;
+export const dynamic = 'force-static';
+export async function generateStaticParams() {}
+export const metadata = {};
+export default function Post() {}
+`)
+	assert.Equal(t, []string{"metadata", "generateStaticParams", "dynamic", "revalidate"}, ServerOnlyExports(content))
+	assert.Empty(t, ServerOnlyExports([]byte("export default function Post() {}")))
+}
@@ -0,0 +1,99 @@
+package routes
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// IgnoredDirs are never searched for source files.
+var IgnoredDirs = []string{"node_modules", ".next", ".git", "out", "dist", "build", "coverage"}
+
+// Link is a static internal URL referenced from a source file.
+type Link struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Href   string `json:"href"`
+	Source string `json:"source"`
+}
+
+var (
+	// href="/x", href='/x', href={"/x"} and href={`/x`} on <Link> and <a>
+	hrefPattern = regexp.MustCompile("\\bhref\\s*=\\s*\\{?\\s*[\"'`](/[^\"'`]*)[\"'`]")
+	// router.push("/x"), router.replace("/x"), redirect("/x"), permanentRedirect("/x")
+	callPattern = regexp.MustCompile("\\b(router\\.(?:push|replace|prefetch)|permanentRedirect|redirect)\\(\\s*[\"'`](/[^\"'`]*)[\"'`]")
+)
+
+// WalkSources calls fn for every source file under root, skipping dependency and build directories.
+func WalkSources(fs afero.Fs, root string, fn func(path string) error) error {
+	return afero.Walk(fs, root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path == root {
+				return nil
+			}
+			for _, ignored := range IgnoredDirs {
+				if info.Name() == ignored {
+					return filepath.SkipDir
+				}
+			}
+			if strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !IsSourceFile(info.Name()) {
+			return nil
+		}
+		return fn(path)
+	})
+}
+
+// ExtractLinks returns every static internal link found in the file contents.
+func ExtractLinks(file string, content []byte) []Link {
+	var found []Link
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		for _, m := range hrefPattern.FindAllStringSubmatch(text, -1) {
+			if isStaticHref(m[1]) {
+				found = append(found, Link{File: file, Line: line, Href: m[1], Source: "href"})
+			}
+		}
+		for _, m := range callPattern.FindAllStringSubmatch(text, -1) {
+			if isStaticHref(m[2]) {
+				found = append(found, Link{File: file, Line: line, Href: m[2], Source: m[1]})
+			}
+		}
+	}
+	return found
+}
+
+// FindLinks collects the static internal links of every source file under root.
+func FindLinks(fs afero.Fs, root string) ([]Link, error) {
+	var found []Link
+	err := WalkSources(fs, root, func(path string) error {
+		content, err := afero.ReadFile(fs, path)
+		if err != nil {
+			return err
+		}
+		found = append(found, ExtractLinks(path, content)...)
+		return nil
+	})
+	return found, err
+}
+
+// isStaticHref filters out protocol-relative URLs and template literals with interpolation.
+func isStaticHref(href string) bool {
+	return !strings.HasPrefix(href, "//") && !strings.Contains(href, "${")
+}
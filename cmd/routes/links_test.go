@@ -0,0 +1,54 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractLinks(t *testing.T) {
+	content := []byte(`import Link from "next/link";
+
+export default function Nav() {
+  router.push('/dashboard?tab=1');
+  return <Link href="/blog/hello">Blog</Link> <a href={` + "`/about`" + `}>About</a>;
+}
+redirect("/login"); <a href="https://example.com">x</a> <Link href={` + "`/u/${id}`" + `} />
+`)
+
+	links := ExtractLinks("nav.tsx", content)
+	assert.Equal(t, []Link{
+		{File: "nav.tsx", Line: 4, Href: "/dashboard?tab=1", Source: "router.push"},
+		{File: "nav.tsx", Line: 5, Href: "/blog/hello", Source: "href"},
+		{File: "nav.tsx", Line: 5, Href: "/about", Source: "href"},
+		{File: "nav.tsx", Line: 7, Href: "/login", Source: "redirect"},
+	}, links)
+}
+
+func TestResolve(t *testing.T) {
+	found := []Route{
+		{URL: "/", File: "root"},
+		{URL: "/blog/[slug]", File: "post"},
+		{URL: "/blog/new", File: "new"},
+		{URL: "/docs/[...rest]", File: "docs"},
+		{URL: "/shop/[[...rest]]", File: "shop"},
+	}
+
+	cases := map[string]string{
+		"/":             "root",
+		"/blog/hello/":  "post",
+		"/blog/new":     "new",
+		"/docs/a/b":     "docs",
+		"/shop":         "shop",
+		"/shop/a/b?x=1": "shop",
+	}
+	for url, file := range cases {
+		route := Resolve(found, url)
+		if assert.NotNil(t, route, url) {
+			assert.Equal(t, file, route.File, url)
+		}
+	}
+
+	assert.Nil(t, Resolve(found, "/docs"))
+	assert.Nil(t, Resolve(found, "/blog"))
+}
@@ -0,0 +1,90 @@
+package routes
+
+import (
+	"strings"
+)
+
+// Segment ranks used to pick the most specific route, mirroring Next.js precedence.
+const (
+	staticRank = iota
+	dynamicRank
+	catchAllRank
+	optionalCatchAllRank
+)
+
+// CleanURL strips the query string, hash and trailing slash from an internal URL.
+func CleanURL(url string) string {
+	if i := strings.IndexAny(url, "?#"); i != -1 {
+		url = url[:i]
+	}
+	if url != "/" {
+		url = strings.TrimSuffix(url, "/")
+	}
+	if url == "" {
+		return "/"
+	}
+	return url
+}
+
+// Match reports whether the url is served by the route pattern, returning the rank of each
+// matched segment so callers can prefer static segments over dynamic ones.
+func Match(pattern string, url string) ([]int, bool) {
+	patternParts := SplitURL(pattern)
+	urlParts := SplitURL(CleanURL(url))
+
+	var ranks []int
+	for i, raw := range patternParts {
+		seg, err := ParseSegment(raw)
+		if err != nil {
+			return nil, false
+		}
+		switch {
+		case seg.CatchAll && seg.Optional:
+			return append(ranks, optionalCatchAllRank), true
+		case seg.CatchAll:
+			if i >= len(urlParts) {
+				return nil, false
+			}
+			return append(ranks, catchAllRank), true
+		case i >= len(urlParts):
+			return nil, false
+		case seg.Dynamic:
+			ranks = append(ranks, dynamicRank)
+		case raw != urlParts[i]:
+			return nil, false
+		default:
+			ranks = append(ranks, staticRank)
+		}
+	}
+	if len(patternParts) != len(urlParts) {
+		return nil, false
+	}
+	return ranks, true
+}
+
+// Resolve returns the route that serves the url, or nil when no route matches.
+func Resolve(found []Route, url string) *Route {
+	var best *Route
+	var bestRanks []int
+	for i := range found {
+		ranks, ok := Match(found[i].URL, url)
+		if !ok {
+			continue
+		}
+		if best == nil || moreSpecific(ranks, bestRanks) {
+			best = &found[i]
+			bestRanks = ranks
+		}
+	}
+	return best
+}
+
+// moreSpecific compares two rank lists segment by segment; lower ranks win.
+func moreSpecific(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) > len(b)
+}
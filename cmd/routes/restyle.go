@@ -0,0 +1,274 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+)
+
+var (
+	defaultFunctionHeader = regexp.MustCompile(`(?m)^export\s+default\s+(async\s+)?function\b\s*`)
+	identifierPrefix      = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*`)
+	defaultExportLine     = regexp.MustCompile(`(?m)^export\s+default\s+([A-Za-z_$][A-Za-z0-9_$]*)[ \t]*;?[ \t]*$`)
+)
+
+// component is a default-exported component split into the parts both styles share.
+type component struct {
+	Name       string
+	Async      bool
+	Generics   string // including the angle brackets
+	Params     string // including the parentheses
+	ReturnType string // including the leading colon
+	Body       string // including the braces
+}
+
+// Restyle rewrites the default-exported component of a file to the given style, keeping its
+// props, generics and body. tsx tells whether generics need the trailing comma of .tsx arrows.
+// An error explains why the file could not be transformed safely.
+func Restyle(content string, to constants.ComponentStyleType, tsx bool) (string, error) {
+	from := DetectComponentStyle([]byte(content))
+	switch {
+	case from == "":
+		return "", errors.New("the default export is not a function or arrow function component")
+	case from == to:
+		return content, nil
+	case to == constants.Const:
+		return functionToConst(content, tsx)
+	}
+	return constToFunction(content)
+}
+
+func functionToConst(content string, tsx bool) (string, error) {
+	loc := defaultFunctionHeader.FindStringSubmatchIndex(content)
+	if loc == nil {
+		return "", errors.New("the default export is not a top level function declaration")
+	}
+	c := component{Async: loc[2] != -1}
+	i := loc[1]
+	c.Name = identifierPrefix.FindString(content[i:])
+	if c.Name == "" {
+		return "", errors.New("the default-exported function has no name")
+	}
+	i += len(c.Name)
+
+	end, err := c.parseSignature(content, i)
+	if err != nil {
+		return "", err
+	}
+	i = skipTrivia(content, end)
+	if i >= len(content) || content[i] != '{' {
+		return "", errors.New("could not find the function body")
+	}
+	end, ok := matchBracket(content, i)
+	if !ok {
+		return "", errors.New("unbalanced braces in the function body")
+	}
+	c.Body = content[i:end]
+
+	generics := c.Generics
+	// In .tsx files '<T>' would be read as JSX, '<T,>' is the usual workaround
+	if tsx && generics != "" && !strings.Contains(generics, ",") && !strings.Contains(generics, "extends") {
+		generics = strings.TrimSuffix(generics, ">") + ",>"
+	}
+	async := ""
+	if c.Async {
+		async = "async "
+	}
+	declaration := fmt.Sprintf("const %s = %s%s%s%s => %s;\n\nexport default %s;", c.Name, async, generics, c.Params, c.ReturnType, c.Body, c.Name)
+	return content[:loc[0]] + declaration + content[end:], nil
+}
+
+func constToFunction(content string) (string, error) {
+	exports := defaultExportLine.FindAllStringSubmatchIndex(content, -1)
+	if len(exports) != 1 {
+		return "", errors.New("could not find a single 'export default Name' statement")
+	}
+	export := exports[0]
+	name := content[export[2]:export[3]]
+
+	declaration := regexp.MustCompile(`(?m)^(export\s+)?const\s+` + regexp.QuoteMeta(name) + `\b\s*`)
+	loc := declaration.FindStringSubmatchIndex(content)
+	if loc == nil {
+		return "", fmt.Errorf("could not find the declaration of '%s'", name)
+	}
+	if loc[2] != -1 {
+		return "", fmt.Errorf("'%s' is also a named export", name)
+	}
+	i := loc[1]
+	if i < len(content) && content[i] == ':' {
+		return "", fmt.Errorf("'%s' has a type annotation (e.g. React.FC) that a function declaration cannot keep", name)
+	}
+	if i >= len(content) || content[i] != '=' {
+		return "", fmt.Errorf("could not find the value of '%s'", name)
+	}
+	i = skipTrivia(content, i+1)
+
+	c := component{Name: name}
+	if strings.HasPrefix(content[i:], "async") && !identifierPrefix.MatchString(content[i+5:]) {
+		c.Async = true
+		i = skipTrivia(content, i+5)
+	}
+	if ident := identifierPrefix.FindString(content[i:]); ident != "" {
+		// A single unparenthesised parameter: props => ...
+		c.Params = "(" + ident + ")"
+		i += len(ident)
+	} else {
+		end, err := c.parseSignature(content, i)
+		if err != nil {
+			return "", err
+		}
+		i = end
+	}
+	i = skipTrivia(content, i)
+	if !strings.HasPrefix(content[i:], "=>") {
+		return "", fmt.Errorf("'%s' is not an arrow function", name)
+	}
+	i = skipTrivia(content, i+2)
+
+	var end int
+	switch {
+	case i < len(content) && content[i] == '{':
+		var ok bool
+		if end, ok = matchBracket(content, i); !ok {
+			return "", errors.New("unbalanced braces in the function body")
+		}
+		c.Body = content[i:end]
+	case i < len(content) && content[i] == '(':
+		var ok bool
+		if end, ok = matchBracket(content, i); !ok {
+			return "", errors.New("unbalanced parentheses in the function body")
+		}
+		c.Body = "{\n  return " + content[i:end] + ";\n}"
+	default:
+		return "", errors.New("the arrow function body is neither a block nor a parenthesised expression")
+	}
+	if rest := skipSpaces(content, end); rest < len(content) && content[rest] == ';' {
+		end = rest + 1
+	}
+	if export[0] < end {
+		return "", fmt.Errorf("'export default %s' comes before its declaration", name)
+	}
+
+	async := ""
+	if c.Async {
+		async = "async "
+	}
+	generics := strings.Replace(c.Generics, ",>", ">", 1)
+	function := fmt.Sprintf("export default %sfunction %s%s%s%s %s", async, c.Name, generics, c.Params, c.ReturnType, c.Body)
+
+	// The export statement goes away along with the blank lines separating it from the code before it
+	between := strings.TrimRight(content[end:export[0]], " \t\r\n")
+	return content[:loc[0]] + function + between + content[export[1]:], nil
+}
+
+// parseSignature reads the optional generics, the parameters and the optional return type
+// starting at i, returning the offset after them.
+func (c *component) parseSignature(content string, i int) (int, error) {
+	i = skipTrivia(content, i)
+	if i < len(content) && content[i] == '<' {
+		end, ok := matchBracket(content, i)
+		if !ok {
+			return 0, errors.New("unbalanced generics")
+		}
+		c.Generics = content[i:end]
+		i = skipTrivia(content, end)
+	}
+	if i >= len(content) || content[i] != '(' {
+		return 0, errors.New("could not find the parameters")
+	}
+	end, ok := matchBracket(content, i)
+	if !ok {
+		return 0, errors.New("unbalanced parentheses in the parameters")
+	}
+	c.Params = content[i:end]
+	i = skipTrivia(content, end)
+
+	if i < len(content) && content[i] == ':' {
+		start := i
+		i = skipTrivia(content, i+1)
+		if i < len(content) && content[i] == '{' {
+			return 0, errors.New("object literal return types are not supported")
+		}
+		for i < len(content) && content[i] != '{' && !strings.HasPrefix(content[i:], "=>") {
+			if content[i] == '<' || content[i] == '(' || content[i] == '[' {
+				if end, ok = matchBracket(content, i); !ok {
+					return 0, errors.New("unbalanced return type")
+				}
+				i = end
+				continue
+			}
+			i++
+		}
+		c.ReturnType = strings.TrimRight(content[start:i], " \t\r\n")
+		return start + len(c.ReturnType), nil
+	}
+	return end, nil
+}
+
+// matchBracket returns the offset after the bracket closing the one at i, skipping
+// comments, strings and template literals.
+func matchBracket(content string, i int) (int, bool) {
+	closing := map[byte]byte{'(': ')', '[': ']', '{': '}', '<': '>'}
+	var stack []byte
+	for i < len(content) {
+		ch := content[i]
+		switch {
+		case strings.HasPrefix(content[i:], "//") || strings.HasPrefix(content[i:], "/*"):
+			i = skipTrivia(content, i)
+			continue
+		case ch == '\'' || ch == '"':
+			// Apostrophes in JSX text never span lines, so a string ends at the line break at the latest
+			i++
+			for i < len(content) && content[i] != ch && content[i] != '\n' {
+				if content[i] == '\\' {
+					i++
+				}
+				i++
+			}
+		case ch == '`':
+			end, ok := skipTemplateLiteral(content, i)
+			if !ok {
+				return 0, false
+			}
+			i = end
+			continue
+		case ch == '(' || ch == '[' || ch == '{' || (ch == '<' && len(stack) == 0):
+			stack = append(stack, closing[ch])
+		case ch == '<' && stack[len(stack)-1] == '>':
+			stack = append(stack, '>')
+		case ch == '=' && strings.HasPrefix(content[i:], "=>"):
+			// Arrows within generics or parameter types are not closing brackets
+			i += 2
+			continue
+		case len(stack) > 0 && ch == stack[len(stack)-1]:
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return i + 1, true
+			}
+		}
+		i++
+	}
+	return 0, false
+}
+
+// skipTemplateLiteral returns the offset after the template literal starting at i.
+func skipTemplateLiteral(content string, i int) (int, bool) {
+	for i++; i < len(content); i++ {
+		switch {
+		case content[i] == '\\':
+			i++
+		case content[i] == '`':
+			return i + 1, true
+		case strings.HasPrefix(content[i:], "${"):
+			end, ok := matchBracket(content, i+1)
+			if !ok {
+				return 0, false
+			}
+			i = end - 1
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,57 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestyle(t *testing.T) {
+	function := `import { List } from './list';
+
+// Renders the posts
+export default async function Posts<T extends { id: string }>({ items = [] }: { items?: T[] }): Promise<JSX.Element> {
+  const label = items.length > 0 ? 'Posts' : "Don't";
+  return <List items={items} render={(item) => <p key={item.id}>{` + "`${item.id}}`" + `}</p>} />;
+}
+`
+	constStyle := `import { List } from './list';
+
+// Renders the posts
+const Posts = async <T extends { id: string }>({ items = [] }: { items?: T[] }): Promise<JSX.Element> => {
+  const label = items.length > 0 ? 'Posts' : "Don't";
+  return <List items={items} render={(item) => <p key={item.id}>{` + "`${item.id}}`" + `}</p>} />;
+};
+
+export default Posts;
+`
+	converted, err := Restyle(function, constants.Const, true)
+	assert.NoError(t, err)
+	assert.Equal(t, constStyle, converted)
+
+	converted, err = Restyle(constStyle, constants.Function, true)
+	assert.NoError(t, err)
+	assert.Equal(t, function, converted)
+
+	converted, err = Restyle("export default function Box<T>(props: T) {\n  return null;\n}", constants.Const, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "const Box = <T,>(props: T) => {\n  return null;\n};\n\nexport default Box;", converted)
+
+	converted, err = Restyle("const Home = () => (\n  <h1>Home</h1>\n);\n\nHome.title = 'Home';\n\nexport default Home;\n", constants.Function, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "export default function Home() {\n  return (\n  <h1>Home</h1>\n);\n}\n\nHome.title = 'Home';\n", converted)
+
+	for _, unsafe := range []string{
+		"const Page: React.FC<Props> = (props) => {\n  return null;\n};\n\nexport default Page;",
+		"export const Page = () => {\n  return null;\n};\n\nexport default Page;",
+		"export default memo(Page);",
+		"export default function () {\n  return null;\n}",
+	} {
+		_, err := Restyle(unsafe, constants.Function, true)
+		if DetectComponentStyle([]byte(unsafe)) == constants.Function {
+			_, err = Restyle(unsafe, constants.Const, true)
+		}
+		assert.Error(t, err, unsafe)
+	}
+}
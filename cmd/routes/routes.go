@@ -0,0 +1,221 @@
+package routes
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/spf13/afero"
+)
+
+type Kind string
+
+const (
+	PageKind Kind = "page"
+	APIKind  Kind = "api"
+)
+
+// SourceExtensions are the file extensions Next.js picks up as routes.
+var SourceExtensions = []string{".tsx", ".ts", ".jsx", ".js"}
+
+// AppSpecialFiles are the app router file conventions living next to a page.
+var AppSpecialFiles = []string{"layout", "template", "loading", "error", "not-found", "global-error", "default"}
+
+// PagesSpecialFiles are the pages router files that don't map to a route.
+var PagesSpecialFiles = []string{"_app", "_document", "_error"}
+
+// Route is a single page or API endpoint found in the project.
+type Route struct {
+	URL     string            `json:"url"`
+	File    string            `json:"file"`
+	Dir     string            `json:"dir"`
+	Kind    Kind              `json:"kind"`
+	Params  []string          `json:"params,omitempty"`
+	Special map[string]string `json:"special,omitempty"`
+}
+
+// Dynamic reports whether the route has any dynamic segments.
+func (r Route) Dynamic() bool {
+	return len(r.Params) > 0
+}
+
+// Location returns the route's path under the routes directory as passed to 'add' (e.g. "(shop)/cart"),
+// or "" for the root page.
+func (r Route) Location(config *constants.Config) string {
+	var rel string
+	if config.Router == constants.AppRouter {
+		rel, _ = filepath.Rel(config.RoutesDir(), r.Dir)
+	} else {
+		rel, _ = filepath.Rel(config.RoutesDir(), strings.TrimSuffix(r.File, filepath.Ext(r.File)))
+		rel = strings.TrimSuffix(filepath.ToSlash(rel), "/index")
+		if rel == "index" {
+			rel = "."
+		}
+	}
+	if rel == "." {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// IsSourceFile reports whether the file name has a route source extension.
+func IsSourceFile(name string) bool {
+	ext := filepath.Ext(name)
+	for _, e := range SourceExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// Scan walks the routes directory of the configured router and returns every route found, sorted by URL.
+func Scan(fs afero.Fs, config *constants.Config) ([]Route, error) {
+	baseDir := config.RoutesDir()
+	if exists, _ := afero.DirExists(fs, baseDir); !exists {
+		return nil, nil
+	}
+
+	var found []Route
+	var err error
+	if config.Router == constants.AppRouter {
+		found, err = scanApp(fs, baseDir)
+	} else {
+		found, err = scanPages(fs, baseDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].URL == found[j].URL {
+			return found[i].File < found[j].File
+		}
+		return found[i].URL < found[j].URL
+	})
+	return found, nil
+}
+
+func scanApp(fs afero.Fs, baseDir string) ([]Route, error) {
+	var found []Route
+	err := afero.Walk(fs, baseDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != baseDir && IsPrivate(info.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !IsSourceFile(info.Name()) {
+			return nil
+		}
+
+		name := strings.TrimSuffix(info.Name(), filepath.Ext(info.Name()))
+		var kind Kind
+		switch name {
+		case "page":
+			kind = PageKind
+		case "route":
+			kind = APIKind
+		default:
+			return nil
+		}
+
+		dir := filepath.Dir(path)
+		url := AppURL(baseDir, dir)
+		found = append(found, Route{
+			URL:     url,
+			File:    path,
+			Dir:     dir,
+			Kind:    kind,
+			Params:  Params(url),
+			Special: specialFilesIn(fs, dir),
+		})
+		return nil
+	})
+	return found, err
+}
+
+func scanPages(fs afero.Fs, baseDir string) ([]Route, error) {
+	var found []Route
+	err := afero.Walk(fs, baseDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !IsSourceFile(info.Name()) {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(baseDir, path)
+		rel = filepath.ToSlash(strings.TrimSuffix(rel, filepath.Ext(rel)))
+		for _, special := range PagesSpecialFiles {
+			if rel == special {
+				return nil
+			}
+		}
+
+		kind := PageKind
+		if rel == "api" || strings.HasPrefix(rel, "api/") {
+			kind = APIKind
+		}
+
+		url := "/" + strings.TrimSuffix(rel, "/index")
+		if rel == "index" {
+			url = "/"
+		}
+		found = append(found, Route{
+			URL:    url,
+			File:   path,
+			Dir:    filepath.Dir(path),
+			Kind:   kind,
+			Params: Params(url),
+		})
+		return nil
+	})
+	return found, err
+}
+
+// AppURL converts an app router directory into the URL it serves, dropping route groups and slots.
+func AppURL(baseDir, dir string) string {
+	rel, err := filepath.Rel(baseDir, dir)
+	if err != nil || rel == "." {
+		return "/"
+	}
+	var parts []string
+	for _, part := range strings.Split(filepath.ToSlash(rel), "/") {
+		if IsGroup(part) || IsSlot(part) {
+			continue
+		}
+		parts = append(parts, part)
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// specialFilesIn returns the app router special files present in the directory, keyed by convention name.
+func specialFilesIn(fs afero.Fs, dir string) map[string]string {
+	special := make(map[string]string)
+	for _, name := range AppSpecialFiles {
+		if file := FindSource(fs, dir, name); file != "" {
+			special[name] = file
+		}
+	}
+	if len(special) == 0 {
+		return nil
+	}
+	return special
+}
+
+// FindSource returns the path of dir/name with the first matching source extension, or "" when none exists.
+func FindSource(fs afero.Fs, dir string, name string) string {
+	for _, ext := range SourceExtensions {
+		candidate := filepath.Join(dir, name+ext)
+		if exists, _ := afero.Exists(fs, candidate); exists {
+			return candidate
+		}
+	}
+	return ""
+}
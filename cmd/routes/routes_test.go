@@ -0,0 +1,118 @@
+package routes
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, fs afero.Fs, files ...string) {
+	t.Helper()
+	for _, file := range files {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(file), []byte("export default function X() {}"), 0644))
+	}
+}
+
+func urls(found []Route) []string {
+	var out []string
+	for _, route := range found {
+		out = append(out, route.URL)
+	}
+	return out
+}
+
+func TestScanAppRouter(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeFiles(t, fs,
+		"app/page.tsx",
+		"app/layout.tsx",
+		"app/(marketing)/about/page.tsx",
+		"app/blog/[slug]/page.tsx",
+		"app/blog/[slug]/loading.tsx",
+		"app/blog/_components/Card.tsx",
+		"app/api/users/route.ts",
+		"app/@modal/login/page.tsx",
+	)
+
+	found, err := Scan(fs, &constants.Config{Router: constants.AppRouter})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/", "/about", "/api/users", "/blog/[slug]", "/login"}, urls(found))
+
+	blog := found[3]
+	assert.Equal(t, []string{"slug"}, blog.Params)
+	assert.Equal(t, PageKind, blog.Kind)
+	assert.Equal(t, filepath.Join("app", "blog", "[slug]", "loading.tsx"), blog.Special["loading"])
+	assert.Equal(t, APIKind, found[2].Kind)
+}
+
+func TestScanPagesRouter(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeFiles(t, fs,
+		"src/pages/_app.tsx",
+		"src/pages/index.tsx",
+		"src/pages/blog/index.tsx",
+		"src/pages/blog/[slug].tsx",
+		"src/pages/api/hello.ts",
+	)
+
+	found, err := Scan(fs, &constants.Config{Router: constants.PagesRouter, SrcFolder: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/", "/api/hello", "/blog", "/blog/[slug]"}, urls(found))
+	assert.Equal(t, APIKind, found[1].Kind)
+}
+
+func TestCheck(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	writeFiles(t, fs,
+		"pages/about.tsx",
+		"pages/about/index.tsx",
+		"pages/blog/[id].tsx",
+		"pages/blog/[slug]/edit.tsx",
+		"pages/docs/[...rest]/more.tsx",
+		"pages/shop/[item.tsx",
+	)
+
+	found, err := Scan(fs, &constants.Config{Router: constants.PagesRouter})
+	assert.NoError(t, err)
+
+	issues := Check(found)
+	var files []string
+	for _, issue := range issues {
+		files = append(files, filepath.ToSlash(issue.File))
+	}
+	assert.Equal(t, []string{
+		"pages/about.tsx",
+		"pages/about/index.tsx",
+		"pages/blog/[id].tsx",
+		"pages/blog/[slug]/edit.tsx",
+		"pages/docs/[...rest]/more.tsx",
+		"pages/shop/[item.tsx",
+	}, files)
+
+	// Parallel slots render next to the page of the same URL
+	fs = afero.NewMemMapFs()
+	writeFiles(t, fs,
+		"app/page.tsx",
+		"app/@modal/page.tsx",
+		"app/@modal/(group)/page.tsx",
+	)
+	found, err = Scan(fs, &constants.Config{Router: constants.AppRouter})
+	assert.NoError(t, err)
+	issues = Check(found)
+	assert.Len(t, issues, 2)
+	for _, issue := range issues {
+		assert.Contains(t, filepath.ToSlash(issue.File), "app/@modal/")
+	}
+}
+
+func TestParseSegment(t *testing.T) {
+	seg, err := ParseSegment("[[...slug]]")
+	assert.NoError(t, err)
+	assert.Equal(t, Segment{Raw: "[[...slug]]", Name: "slug", Dynamic: true, CatchAll: true, Optional: true}, seg)
+
+	_, err = ParseSegment("[[id]]")
+	assert.Error(t, err)
+}
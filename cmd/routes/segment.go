@@ -0,0 +1,84 @@
+package routes
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Segment is a single parsed part of a route path (e.g. "blog", "[slug]", "[...rest]").
+type Segment struct {
+	Raw      string
+	Name     string
+	Dynamic  bool
+	CatchAll bool
+	Optional bool
+}
+
+// ParseSegment parses a directory or file name segment into its Next.js meaning.
+func ParseSegment(raw string) (Segment, error) {
+	seg := Segment{Raw: raw, Name: raw}
+	if !strings.ContainsAny(raw, "[]") {
+		return seg, nil
+	}
+
+	inner := raw
+	switch {
+	case strings.HasPrefix(inner, "[[") && strings.HasSuffix(inner, "]]"):
+		inner = inner[2 : len(inner)-2]
+		seg.Optional = true
+	case strings.HasPrefix(inner, "[") && strings.HasSuffix(inner, "]"):
+		inner = inner[1 : len(inner)-1]
+	default:
+		return seg, fmt.Errorf("unbalanced brackets in segment '%s'", raw)
+	}
+
+	if strings.HasPrefix(inner, "...") {
+		inner = strings.TrimPrefix(inner, "...")
+		seg.CatchAll = true
+	}
+	if seg.Optional && !seg.CatchAll {
+		return seg, fmt.Errorf("optional segment '%s' must be a catch-all ([[...name]])", raw)
+	}
+	if inner == "" || strings.ContainsAny(inner, "[]./") {
+		return seg, fmt.Errorf("invalid dynamic segment '%s'", raw)
+	}
+
+	seg.Name = inner
+	seg.Dynamic = true
+	return seg, nil
+}
+
+// IsGroup reports whether the segment is an app router route group, e.g. "(marketing)".
+func IsGroup(raw string) bool {
+	return strings.HasPrefix(raw, "(") && strings.HasSuffix(raw, ")")
+}
+
+// IsSlot reports whether the segment is an app router parallel route slot, e.g. "@modal".
+func IsSlot(raw string) bool {
+	return strings.HasPrefix(raw, "@")
+}
+
+// IsPrivate reports whether the segment is a private folder, e.g. "_components".
+func IsPrivate(raw string) bool {
+	return strings.HasPrefix(raw, "_")
+}
+
+// SplitURL splits a route URL like "/blog/[slug]" into its segments.
+func SplitURL(url string) []string {
+	trimmed := strings.Trim(url, "/")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "/")
+}
+
+// Params returns the dynamic parameter names found in a route URL.
+func Params(url string) []string {
+	var params []string
+	for _, raw := range SplitURL(url) {
+		if seg, err := ParseSegment(raw); err == nil && seg.Dynamic {
+			params = append(params, seg.Name)
+		}
+	}
+	return params
+}
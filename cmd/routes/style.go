@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"regexp"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+)
+
+var (
+	defaultFunctionPattern = regexp.MustCompile(`(?m)^\s*export\s+default\s+(?:async\s+)?function\b`)
+	defaultNamePattern     = regexp.MustCompile(`(?m)^\s*export\s+default\s+([A-Za-z_$][A-Za-z0-9_$]*)\s*;?\s*$`)
+)
+
+// DetectComponentStyle returns how the default-exported component is declared, or "" when it can't tell.
+func DetectComponentStyle(content []byte) constants.ComponentStyleType {
+	if defaultFunctionPattern.Match(content) {
+		return constants.Function
+	}
+	m := defaultNamePattern.FindSubmatch(content)
+	if m == nil {
+		return ""
+	}
+	arrow := regexp.MustCompile(`(?m)^\s*(?:export\s+)?const\s+` + regexp.QuoteMeta(string(m[1])) + `\b[^=]*=\s*(?:async\s*)?(?:<[^<>]*(?:<[^<>]*>[^<>]*)*>\s*)?(?:\([^)]*\)|[A-Za-z_$][A-Za-z0-9_$]*)\s*(?::[^=]+)?=>`)
+	if arrow.Match(content) {
+		return constants.Const
+	}
+	return ""
+}
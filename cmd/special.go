@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/helpers"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+)
+
+// SpecialFileData holds the dynamic data for the special file templates (layout, loading, error, ...)
+type SpecialFileData struct {
+	ComponentName string
+	Style         constants.ComponentStyleType
+	Language      constants.LanguageType
+	Root          bool
+	// Body replaces the generated component when set, for templates supporting it
+	Body string
+	// Params are the dynamic segments of the route directory
+	Params      PageParams
+	NextVersion string
+	AsyncParams bool
+}
+
+// RouteHandlerData holds the dynamic data for the route handler template
+type RouteHandlerData struct {
+	URL         string
+	Router      constants.RouterType
+	Language    constants.LanguageType
+	Params      PageParams
+	NextVersion string
+	AsyncParams bool
+}
+
+// componentExtension returns the extension of generated component files.
+func componentExtension(config *constants.Config) string {
+	if config.Language == constants.Javascript {
+		return ".jsx"
+	}
+	return ".tsx"
+}
+
+// scriptExtension returns the extension of generated non-component files (route handlers).
+func scriptExtension(config *constants.Config) string {
+	if config.Language == constants.Javascript {
+		return ".js"
+	}
+	return ".ts"
+}
+
+// specialFilePath returns where a special file of the route directory lives.
+func specialFilePath(config *constants.Config, routeDir string, name string) string {
+	return filepath.Join(routeDir, name+componentExtension(config))
+}
+
+// generateSpecialFileContent renders a special file (e.g. "layout") for the route directory.
+func generateSpecialFileContent(name string, routeDir string, config *constants.Config) (string, error) {
+	return renderSpecialFile(name, routeDir, config, nextVersion(AppFs, config))
+}
+
+// renderSpecialFile is generateSpecialFileContent with the Next.js version already known
+func renderSpecialFile(name string, routeDir string, config *constants.Config, version string) (string, error) {
+	base := "Root"
+	if routeDir != config.RoutesDir() {
+		base = helpers.ToPascalCase(filepath.Base(routeDir))
+	}
+	data := SpecialFileData{
+		ComponentName: base + helpers.ToPascalCase(name),
+		Style:         config.ComponentStyle,
+		Language:      config.Language,
+		Root:          routeDir == config.RoutesDir(),
+		Params:        urlParams(routes.AppURL(config.RoutesDir(), routeDir)),
+		NextVersion:   version,
+		AsyncParams:   usesAsyncParams(version),
+	}
+	return generateFileContent(name, data)
+}
+
+// routeHandlerPath returns where the API route for the page name input lives.
+func routeHandlerPath(config *constants.Config, pageNameInput string) string {
+	if config.Router == constants.AppRouter {
+		return filepath.Join(config.RoutesDir(), filepath.FromSlash(pageNameInput), "route"+scriptExtension(config))
+	}
+	return filepath.Join(config.RoutesDir(), filepath.FromSlash(pageNameInput)+scriptExtension(config))
+}
+
+// generateRouteHandlerContent renders an API route handler for the page name input.
+func generateRouteHandlerContent(pageNameInput string, config *constants.Config) (string, error) {
+	url := routeURL(config, pageNameInput)
+	version := nextVersion(AppFs, config)
+	data := RouteHandlerData{
+		URL:         url,
+		Router:      config.Router,
+		Language:    config.Language,
+		Params:      urlParams(url),
+		NextVersion: version,
+		AsyncParams: usesAsyncParams(version),
+	}
+	return generateFileContent("route", data)
+}
+
+// routeURL returns the URL a page name input is served at.
+func routeURL(config *constants.Config, pageNameInput string) string {
+	input := strings.Trim(filepath.ToSlash(pageNameInput), "/")
+	if config.Router == constants.AppRouter {
+		return routes.AppURL(config.RoutesDir(), filepath.Join(config.RoutesDir(), filepath.FromSlash(input)))
+	}
+	if input == "index" {
+		return "/"
+	}
+	return routes.CleanURL("/" + strings.TrimSuffix(input, "/index"))
+}
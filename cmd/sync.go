@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/helpers"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync --flag",
+	Short: "Reconciles your Next.js project with the route manifest.",
+	Long: fmt.Sprintf(`Reads the route manifest (%s by default) and reconciles the filesystem with it.
+- Missing pages, route handlers and special files are created.
+- Routes that are not in the manifest are reported, and removed with --prune.
+- Running it twice is a no-op.
+- With --check nothing is written and the command exits non-zero on drift.
+
+Example manifest:
+  routes:
+    - path: /
+    - path: blog/[slug]
+      client: true
+      special: [layout, loading]
+    - path: api/users
+      kind: api
+`, constants.ManifestFileName),
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		checkFlag, _ := cmd.Flags().GetBool("check")
+		pruneFlag, _ := cmd.Flags().GetBool("prune")
+		manifestFlag, _ := cmd.Flags().GetString("manifest")
+
+		config, err := constants.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading configuration:\n%v\n", err)
+			os.Exit(1)
+		}
+		manifest, err := constants.LoadManifest(AppFs, manifestFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading manifest:\n%v\n", err)
+			os.Exit(1)
+		}
+		found, err := routes.Scan(AppFs, config)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error scanning routes:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		plan, err := planSync(AppFs, config, manifest, found)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error planning sync:\n%v\n", err)
+			os.Exit(1)
+		}
+
+		if checkFlag {
+			printSyncPlan(plan, manifestFlag)
+			if plan.Drifted() {
+				os.Exit(1)
+			}
+			return
+		}
+
+		for _, file := range plan.Create {
+			if err := createPageFile(AppFs, file.Path, file.Content); err != nil {
+				fmt.Fprintf(os.Stderr, "Error creating file:\n%v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Created %s\n", file.Path)
+		}
+		if pruneFlag {
+			for _, route := range plan.Extra {
+				if _, err := removeRoute(AppFs, config, route, false); err != nil {
+					fmt.Fprintf(os.Stderr, "Error removing %s:\n%v\n", route.URL, err)
+					os.Exit(1)
+				}
+				fmt.Printf("Removed %s\n", route.URL)
+			}
+			plan.Extra = nil
+		}
+		plan.Create = nil
+		printSyncPlan(plan, manifestFlag)
+	},
+}
+
+// GeneratedFile is a file to be created with its rendered content.
+type GeneratedFile struct {
+	Path    string
+	Content string
+}
+
+// SyncPlan is the difference between the manifest and the filesystem.
+type SyncPlan struct {
+	Create   []GeneratedFile
+	Extra    []routes.Route
+	Mismatch []string
+}
+
+// Drifted reports whether the filesystem differs from the manifest.
+func (p *SyncPlan) Drifted() bool {
+	return len(p.Create) > 0 || len(p.Extra) > 0 || len(p.Mismatch) > 0
+}
+
+// resolvePage returns the page file and component name for a manifest path, including the root page.
+func resolvePage(pageNameInput string, config *constants.Config) (string, string, error) {
+	input := strings.Trim(filepath.ToSlash(pageNameInput), "/")
+	if input != "" && input != "index" {
+		return determinePathAndComponent(input, config)
+	}
+
+	name := "page"
+	if config.Router == constants.PagesRouter {
+		name = "index"
+	}
+	componentName := "Home"
+	if config.PageComponentSuffix != "" {
+		componentName += helpers.ToPascalCase(config.PageComponentSuffix)
+	}
+	return filepath.Join(config.RoutesDir(), name+componentExtension(config)), componentName, nil
+}
+
+// planSync compares the manifest with the scanned routes.
+func planSync(fs afero.Fs, config *constants.Config, manifest *constants.Manifest, found []routes.Route) (*SyncPlan, error) {
+	plan := &SyncPlan{}
+	wanted := make(map[string]bool)
+	planned := make(map[string]bool)
+
+	for _, entry := range manifest.Routes {
+		config := config
+		if entry.Style != "" {
+			styled := *config
+			styled.ComponentStyle = entry.Style
+			config = &styled
+		}
+
+		input := strings.Trim(filepath.ToSlash(entry.Path), "/")
+		kind := routes.Kind(entry.Kind)
+		url := routeURL(config, input)
+		wanted[string(kind)+" "+url] = true
+
+		var existing *routes.Route
+		for i := range found {
+			if found[i].URL == url && found[i].Kind == kind {
+				existing = &found[i]
+				break
+			}
+		}
+
+		var dir string
+		switch {
+		case existing != nil:
+			dir = existing.Dir
+			if kind == routes.PageKind && config.Router == constants.AppRouter {
+				content, err := afero.ReadFile(fs, existing.File)
+				if err != nil {
+					return nil, fmt.Errorf("could not read file '%s': %w", existing.File, err)
+				}
+				if routes.IsClientComponent(content) != entry.Client {
+					plan.Mismatch = append(plan.Mismatch, fmt.Sprintf("%s: 'use client' is %t in the manifest but %t in the file", existing.File, entry.Client, !entry.Client))
+				}
+			}
+		case kind == routes.APIKind:
+			path := routeHandlerPath(config, input)
+			content, err := generateRouteHandlerContent(input, config)
+			if err != nil {
+				return nil, err
+			}
+			plan.Create = append(plan.Create, GeneratedFile{Path: path, Content: content})
+			dir = filepath.Dir(path)
+		default:
+			path, componentName, err := resolvePage(input, config)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path '%s': %w", entry.Path, err)
+			}
+			template := entry.Template
+			if template == "" {
+				template = "page"
+			}
+			data := newPageData(input, componentName, config, entry.Client)
+			content, err := generateFileContent(template, data)
+			if err != nil {
+				return nil, err
+			}
+			plan.Create = append(plan.Create, GeneratedFile{Path: path, Content: content})
+			stylesheets, err := pageStylesheet(fs, path, data)
+			if err != nil {
+				return nil, err
+			}
+			plan.Create = append(plan.Create, stylesheets...)
+			dir = filepath.Dir(path)
+		}
+
+		if len(entry.Special) > 0 && config.Router != constants.AppRouter {
+			return nil, fmt.Errorf("special files of '%s' are only supported by the app router", entry.Path)
+		}
+		for _, name := range entry.Special {
+			if !isAppSpecialFile(name) {
+				return nil, fmt.Errorf("unknown special file '%s' for '%s', expected one of: %s", name, entry.Path, strings.Join(routes.AppSpecialFiles, ", "))
+			}
+			if routes.FindSource(fs, dir, name) != "" {
+				continue
+			}
+			path := specialFilePath(config, dir, name)
+			if planned[path] {
+				continue
+			}
+			planned[path] = true
+			content, err := generateSpecialFileContent(name, dir, config)
+			if err != nil {
+				return nil, err
+			}
+			plan.Create = append(plan.Create, GeneratedFile{Path: path, Content: content})
+		}
+	}
+
+	for _, route := range found {
+		if !wanted[string(route.Kind)+" "+route.URL] {
+			plan.Extra = append(plan.Extra, route)
+		}
+	}
+	return plan, nil
+}
+
+func isAppSpecialFile(name string) bool {
+	for _, special := range routes.AppSpecialFiles {
+		if name == special {
+			return true
+		}
+	}
+	return false
+}
+
+func printSyncPlan(plan *SyncPlan, manifestPath string) {
+	if !plan.Drifted() {
+		fmt.Printf("Routes are in sync with %s.\n", manifestPath)
+		return
+	}
+	if len(plan.Create) > 0 {
+		fmt.Printf("Missing files (%d):\n", len(plan.Create))
+		for _, file := range plan.Create {
+			fmt.Printf("  %s\n", file.Path)
+		}
+	}
+	if len(plan.Extra) > 0 {
+		fmt.Printf("Routes not in %s (%d):\n", manifestPath, len(plan.Extra))
+		for _, route := range plan.Extra {
+			fmt.Printf("  %s  %s\n", route.URL, route.File)
+		}
+	}
+	if len(plan.Mismatch) > 0 {
+		fmt.Printf("Mismatches (%d):\n", len(plan.Mismatch))
+		for _, mismatch := range plan.Mismatch {
+			fmt.Printf("  %s\n", mismatch)
+		}
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+	syncCmd.Flags().Bool("check", false, "Report drift without writing anything and exit non-zero when found")
+	syncCmd.Flags().Bool("prune", false, "Remove routes that are not in the manifest")
+	syncCmd.Flags().String("manifest", constants.ManifestFileName, "Path to the route manifest (YAML or JSON)")
+}
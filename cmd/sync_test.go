@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanSync(t *testing.T) {
+	fs := useTemplateFs(t)
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: constants.Function, PageComponentSuffix: "page"}
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join("app", "page.tsx"), []byte(""), 0644))
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join("app", "old", "page.tsx"), []byte(""), 0644))
+
+	manifest := &constants.Manifest{Routes: []constants.ManifestRoute{
+		{Path: "/", Kind: "page", Special: []string{"layout"}},
+		{Path: "blog/[slug]", Kind: "page", Client: true, Special: []string{"loading"}},
+		{Path: "api/users", Kind: "api"},
+	}}
+
+	found, err := routes.Scan(fs, config)
+	assert.NoError(t, err)
+	plan, err := planSync(fs, config, manifest, found)
+	assert.NoError(t, err)
+
+	var created []string
+	for _, file := range plan.Create {
+		created = append(created, filepath.ToSlash(file.Path))
+		assert.NoError(t, createPageFile(fs, file.Path, file.Content))
+	}
+	assert.Equal(t, []string{"app/layout.tsx", "app/blog/[slug]/page.tsx", "app/blog/[slug]/loading.tsx", "app/api/users/route.ts"}, created)
+	assert.Len(t, plan.Extra, 1)
+	assert.Equal(t, "/old", plan.Extra[0].URL)
+	assert.Contains(t, plan.Create[1].Content, "'use client';")
+	assert.Contains(t, plan.Create[1].Content, "SlugPage")
+
+	// A second run has nothing left to create
+	found, err = routes.Scan(fs, config)
+	assert.NoError(t, err)
+	plan, err = planSync(fs, config, manifest, found)
+	assert.NoError(t, err)
+	assert.Empty(t, plan.Create)
+	assert.Empty(t, plan.Mismatch)
+}
+
+func TestPruneKeepsChildRoutes(t *testing.T) {
+	fs := useTemplateFs(t)
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: constants.Function}
+	for _, file := range []string{"app/page.tsx", "app/shop/page.tsx", "app/shop/cart/page.tsx"} {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(file), []byte(""), 0644))
+	}
+	manifest := &constants.Manifest{Routes: []constants.ManifestRoute{
+		{Path: "/", Kind: "page"},
+		{Path: "shop/cart", Kind: "page"},
+	}}
+
+	found, err := routes.Scan(fs, config)
+	assert.NoError(t, err)
+	plan, err := planSync(fs, config, manifest, found)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Extra, 1)
+	for _, route := range plan.Extra {
+		_, err := removeRoute(fs, config, route, false)
+		assert.NoError(t, err)
+	}
+	exists, _ := afero.Exists(fs, filepath.Join("app", "shop", "page.tsx"))
+	assert.False(t, exists)
+	exists, _ = afero.Exists(fs, filepath.Join("app", "shop", "cart", "page.tsx"))
+	assert.True(t, exists)
+}
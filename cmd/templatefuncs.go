@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/helpers"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+)
+
+// Crumb is a step of the breadcrumbs leading to a URL
+type Crumb struct {
+	// Label is the title cased segment name, e.g. "Blog Posts" for "blog-posts" or "Slug" for "[slug]"
+	Label   string
+	URL     string
+	Dynamic bool
+}
+
+// templateFuncs are the functions available to every template
+var templateFuncs = template.FuncMap{
+	// Case conversions
+	"kebab":     helpers.ToKebabCase,
+	"camel":     func(s string) string { return helpers.ToCamelCase(helpers.ToKebabCase(s)) },
+	"snake":     helpers.ToSnakeCase,
+	"pascal":    func(s string) string { return helpers.ToPascalCase(helpers.ToKebabCase(s)) },
+	"title":     helpers.ToTitle,
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"pluralize": helpers.Pluralize,
+
+	// Strings and lists, in pipeline friendly argument order
+	"join":       func(sep string, elems []string) string { return strings.Join(elems, sep) },
+	"split":      func(sep string, s string) []string { return strings.Split(s, sep) },
+	"trimSuffix": func(suffix string, s string) string { return strings.TrimSuffix(s, suffix) },
+	"trimPrefix": func(prefix string, s string) string { return strings.TrimPrefix(s, prefix) },
+
+	// Paths
+	"pathJoin": path.Join,
+	"pathBase": path.Base,
+	"pathDir":  path.Dir,
+	"pathExt":  path.Ext,
+
+	// URLs and segments
+	"urlJoin":     urlJoin,
+	"parentURL":   parentURL,
+	"segmentName": segmentName,
+	"isDynamic":   isDynamicSegment,
+	"breadcrumbs": breadcrumbs,
+}
+
+// urlJoin joins the parts into an absolute URL, e.g. "blog", "[slug]" -> "/blog/[slug]".
+func urlJoin(parts ...string) string {
+	var segments []string
+	for _, part := range parts {
+		segments = append(segments, routes.SplitURL(part)...)
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// parentURL returns the URL one segment up, "/" for top level URLs.
+func parentURL(url string) string {
+	segments := routes.SplitURL(url)
+	if len(segments) == 0 {
+		return "/"
+	}
+	return urlJoin(segments[:len(segments)-1]...)
+}
+
+// segmentName returns the name of a segment without the brackets of dynamic segments or parentheses of groups.
+func segmentName(segment string) string {
+	if seg, err := routes.ParseSegment(segment); err == nil && seg.Dynamic {
+		return seg.Name
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(segment, "("), ")")
+}
+
+func isDynamicSegment(segment string) bool {
+	seg, err := routes.ParseSegment(segment)
+	return err == nil && seg.Dynamic
+}
+
+// breadcrumbs returns the crumbs from the first segment down to the URL itself, the root and route groups excluded.
+func breadcrumbs(url string) []Crumb {
+	var crumbs []Crumb
+	var segments []string
+	for _, segment := range routes.SplitURL(url) {
+		if routes.IsGroup(segment) {
+			continue
+		}
+		segments = append(segments, segment)
+		i := len(segments) - 1
+		crumbs = append(crumbs, Crumb{
+			Label:   helpers.ToTitle(segmentName(segment)),
+			URL:     urlJoin(segments[:i+1]...),
+			Dynamic: isDynamicSegment(segment),
+		})
+	}
+	return crumbs
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	render := func(text string, data any) string {
+		t.Helper()
+		var out strings.Builder
+		tmpl, err := template.New("test").Funcs(templateFuncs).Parse(text)
+		assert.NoError(t, err)
+		assert.NoError(t, tmpl.Execute(&out, data))
+		return out.String()
+	}
+
+	assert.Equal(t, "user-profile user_profile userProfile User Profile", render(`{{ kebab . }} {{ snake . }} {{ camel . }} {{ title . }}`, "UserProfile"))
+	assert.Equal(t, "html-parser", render(`{{ kebab . }}`, "HTMLParser"))
+	assert.Equal(t, "posts categories boxes days", render(`{{ pluralize "post" }} {{ pluralize "category" }} {{ pluralize "box" }} {{ pluralize "day" }}`, nil))
+	assert.Equal(t, "/blog/[slug] /blog / slug true", render(`{{ urlJoin "blog" "/[slug]/" }} {{ parentURL "/blog/[slug]" }} {{ parentURL "/blog" }} {{ segmentName "[...slug]" }} {{ isDynamic "[[...slug]]" }}`, nil))
+	assert.Equal(t, "app/blog/page.tsx page.tsx", render(`{{ pathJoin "app" "blog" "page.tsx" }} {{ pathBase "app/blog/page.tsx" }}`, nil))
+
+	assert.Equal(t, []Crumb{
+		{Label: "Blog Posts", URL: "/blog-posts"},
+		{Label: "Post Id", URL: "/blog-posts/[postId]", Dynamic: true},
+	}, breadcrumbs("/(marketing)/blog-posts/[postId]"))
+}
+
+func TestPageDataContext(t *testing.T) {
+	useTemplateFs(t)
+
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: constants.Function, SrcFolder: true}
+	data := newPageData("(shop)/products/[id]", "ProductPage", config, false)
+	assert.Equal(t, "/products/[id]", data.URL)
+	assert.Equal(t, []string{"products", "[id]"}, data.Segments)
+	assert.Equal(t, PageParams{{Name: "id"}}, data.Params)
+	assert.Equal(t, constants.AppRouter, data.Router)
+	assert.True(t, data.SrcFolder)
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}$`, data.Date)
+
+	var out strings.Builder
+	tmpl, err := template.New("title").Funcs(templateFuncs).Parse(`<title>{{ range $i, $c := breadcrumbs .URL }}{{ if $i }} / {{ end }}{{ $c.Label }}{{ end }}</title>`)
+	assert.NoError(t, err)
+	assert.NoError(t, tmpl.Execute(&out, data))
+	assert.Equal(t, "<title>Products / Id</title>", out.String())
+}
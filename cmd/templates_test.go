@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+// useTemplateFs points AppFs to an in-memory filesystem holding the real templates.
+func useTemplateFs(t *testing.T) afero.Fs {
+	t.Helper()
+	fs := afero.NewMemMapFs()
+	entries, err := os.ReadDir("templates")
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join("templates", entry.Name()))
+		assert.NoError(t, err)
+		assert.NoError(t, afero.WriteFile(fs, "cmd/templates/"+entry.Name(), data, 0644))
+	}
+
+	original := AppFs
+	AppFs = fs
+	t.Cleanup(func() { AppFs = original })
+	return fs
+}
+
+func TestSpecialFileTemplates(t *testing.T) {
+	useTemplateFs(t)
+
+	for _, style := range []constants.ComponentStyleType{constants.Function, constants.Const} {
+		config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: style}
+		for _, name := range []string{"layout", "template", "loading", "error", "not-found", "global-error", "default"} {
+			content, err := generateSpecialFileContent(name, filepath.Join("app", "blog"), config)
+			assert.NoError(t, err, name)
+			assert.Contains(t, content, "Blog", name)
+		}
+	}
+
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: constants.Function}
+	layout, err := generateSpecialFileContent("layout", "app", config)
+	assert.NoError(t, err)
+	assert.Equal(t, `export default function RootLayout({ children }: { children: React.ReactNode }) {
+  return (
+    <html lang="en">
+      <body>{children}</body>
+    </html>
+  );
+}`, layout)
+}
+
+func TestPageDataFetchingTemplate(t *testing.T) {
+	useTemplateFs(t)
+
+	config := &constants.Config{Router: constants.PagesRouter, Language: constants.Typescript, ComponentStyle: constants.Function}
+	data := newPageData("docs/[[...path]]", "DocsPage", config, false)
+	data.Data = "isr"
+	data.Revalidate = defaultRevalidate
+	content, err := generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.Equal(t, PageParams{{Name: "path", CatchAll: true, Optional: true}}, data.Params)
+	assert.Contains(t, content, "type Props = {\n  data: unknown;\n};\n\ntype Params = { path?: string[] };\n\nexport const getStaticPaths")
+	assert.Contains(t, content, "}) satisfies GetStaticPaths<Params>;")
+	assert.Contains(t, content, "return { props: { data }, revalidate: 60 };")
+	assert.Contains(t, content, "export default function DocsPage({ data }: InferGetStaticPropsType<typeof getStaticProps>) {")
+
+	data = newPageData("blog/[slug]", "BlogPage", config, false)
+	data.Data = "ssr"
+	content, err = generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.NotContains(t, content, "getStaticPaths")
+	assert.Contains(t, content, "}) satisfies GetServerSideProps<Props, Params>;")
+	assert.Contains(t, content, "export default function BlogPage({ data }: InferGetServerSidePropsType<typeof getServerSideProps>) {")
+
+	data = newPageData("posts/[post-id]", "PostPage", config, false)
+	data.Data = "ssg"
+	content, err = generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.Contains(t, content, "type Params = { 'post-id': string };")
+
+	config.Language = constants.Javascript
+	data = newPageData("blog/[slug]", "BlogPage", config, false)
+	data.Data = "ssg"
+	content, err = generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.Contains(t, content, "export async function getStaticPaths() {")
+	assert.Contains(t, content, "export default function BlogPage({ data }) {")
+	assert.NotContains(t, content, "satisfies")
+}
+
+func TestAppPageTemplate(t *testing.T) {
+	useTemplateFs(t)
+
+	revalidate := 3600
+	config := &constants.Config{
+		Router:         constants.AppRouter,
+		Language:       constants.Typescript,
+		ComponentStyle: constants.Function,
+		SegmentConfig:  constants.SegmentConfig{Runtime: "edge", Revalidate: &revalidate},
+	}
+	data := newPageData("docs/[...path]", "DocsPage", config, false)
+	data.Async = true
+	data.Metadata = "dynamic"
+	data.StaticParams = true
+	content, err := generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.Contains(t, content, "import type { Metadata } from 'next';\n\nexport const revalidate = 3600;\nexport const runtime = 'edge';\n\n")
+	assert.Contains(t, content, "type Props = {\n  params: Promise<{ path: string[] }>;\n  searchParams: Promise<{ [key: string]: string | string[] | undefined }>;\n};")
+	assert.Contains(t, content, "  const { path } = await params;\n  return {\n    title: `DocsPage ${path.join('/')}`,")
+	assert.Contains(t, content, "export async function generateStaticParams() {\n  // Return the params to pre-render at build time, e.g. [{ path: ['...'] }]")
+	assert.Contains(t, content, "export async function generateMetadata({ params }: Props): Promise<Metadata> {")
+	assert.Contains(t, content, "export default async function DocsPage({ params }: Props) {\n  const { path } = await params;\n  // Fetch the page data here\n  const res = await fetch(")
+
+	data = newPageData("about", "AboutPage", config, false)
+	data.Segment = constants.SegmentConfig{}
+	data.Metadata = "static"
+	content, err = generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(content, "import type { Metadata } from 'next';\n\nexport const metadata: Metadata = {\n  title: 'AboutPage',\n};\n\nexport default function AboutPage() {"))
+
+	// Params that are not valid identifiers are quoted and renamed
+	data = newPageData("posts/[post-id]", "PostPage", config, false)
+	data.Segment = constants.SegmentConfig{}
+	data.Async = true
+	content, err = generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.Contains(t, content, "params: Promise<{ 'post-id': string }>;")
+	assert.Contains(t, content, "const { 'post-id': postId } = await params;")
+
+	// Dynamic pages receive their params without --async too
+	data = newPageData("blog/[slug]", "BlogPage", config, false)
+	data.Segment = constants.SegmentConfig{}
+	content, err = generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.Contains(t, content, "export default async function BlogPage({ params }: Props) {\n  const { slug } = await params;\n  return (")
+	assert.Contains(t, content, "<p>{slug}</p>")
+	assert.NotContains(t, content, "fetch(")
+
+	config.NextVersion = "14.2.0"
+	data = newPageData("blog/[slug]", "BlogPage", config, false)
+	data.Segment = constants.SegmentConfig{}
+	content, err = generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.Contains(t, content, "  params: { slug: string };\n  searchParams: { [key: string]: string | string[] | undefined };")
+	assert.Contains(t, content, "export default function BlogPage({ params }: Props) {\n  const { slug } = params;")
+
+	// Client components can't be async and unwrap the params with use()
+	config.NextVersion = "15.0.0"
+	data = newPageData("blog/[slug]", "BlogPage", config, true)
+	content, err = generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(content, "'use client';\n\nimport { use } from 'react';\n\ntype Props = {"))
+	assert.Contains(t, content, "export default function BlogPage({ params }: Props) {\n  const { slug } = use(params);")
+
+	config.Language = constants.Javascript
+	data = newPageData("docs/[[...path]]", "DocsPage", config, false)
+	data.Segment = constants.SegmentConfig{}
+	content, err = generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(content, "export default async function DocsPage({ params }) {\n  const { path } = await params;"))
+	assert.Contains(t, content, "<p>{path?.join('/') ?? ''}</p>")
+}
+
+func TestNextVersionTemplates(t *testing.T) {
+	fs := useTemplateFs(t)
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: constants.Function}
+
+	assert.Equal(t, "", nextVersion(fs, config))
+	assert.NoError(t, afero.WriteFile(fs, "package.json", []byte(`{"dependencies": {"next": "^14.2.3", "react": "18.3.1"}}`), 0644))
+	assert.Equal(t, "^14.2.3", nextVersion(fs, config))
+	assert.False(t, usesAsyncParams("^14.2.3"))
+	assert.True(t, usesAsyncParams("15.0.0-canary.1"))
+	assert.True(t, usesAsyncParams("latest"))
+
+	layout, err := generateSpecialFileContent("layout", filepath.Join("app", "blog", "[slug]"), config)
+	assert.NoError(t, err)
+	assert.Contains(t, layout, "({ children }: { children: React.ReactNode; params: { slug: string } })")
+	handler, err := generateRouteHandlerContent("api/users/[id]", config)
+	assert.NoError(t, err)
+	assert.Contains(t, handler, "GET(request: Request, { params }: { params: { id: string } }) {\n  return Response.json({ message: 'Hello from /api/users/[id]', params });")
+
+	config.NextVersion = "15.1.0"
+	assert.Equal(t, "15.1.0", nextVersion(fs, config))
+	layout, err = generateSpecialFileContent("layout", filepath.Join("app", "blog", "[slug]"), config)
+	assert.NoError(t, err)
+	assert.Contains(t, layout, "({ children }: { children: React.ReactNode; params: Promise<{ slug: string }> })")
+	handler, err = generateRouteHandlerContent("api/users/[id]", config)
+	assert.NoError(t, err)
+	assert.Contains(t, handler, "GET(request: Request, { params }: { params: Promise<{ id: string }> }) {\n  return Response.json({ message: 'Hello from /api/users/[id]', params: await params });")
+
+	// Layouts only type their params, destructuring them would leave unused variables
+	config.Language = constants.Javascript
+	layout, err = generateSpecialFileContent("layout", filepath.Join("app", "blog", "[slug]"), config)
+	assert.NoError(t, err)
+	assert.Contains(t, layout, "export default function SlugLayout({ children }) {")
+}
+
+func TestPageStyling(t *testing.T) {
+	fs := useTemplateFs(t)
+
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, ComponentStyle: constants.Function, Styling: constants.Sass}
+	data := newPageData("blog", "BlogPage", config, false)
+	assert.Equal(t, "page.module.scss", data.StylesFile)
+	content, err := generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(content, "import styles from './page.module.scss';\n\n"))
+	assert.Contains(t, content, "<div className={styles.root}>")
+
+	pagePath := filepath.Join("app", "blog", "page.tsx")
+	files, err := pageStylesheet(fs, pagePath, data)
+	assert.NoError(t, err)
+	assert.Equal(t, []GeneratedFile{{Path: filepath.Join("app", "blog", "page.module.scss"), Content: ".root {\n}"}}, files)
+	assert.NoError(t, afero.WriteFile(fs, files[0].Path, []byte(files[0].Content), 0644))
+	files, err = pageStylesheet(fs, pagePath, data)
+	assert.NoError(t, err)
+	assert.Empty(t, files)
+
+	config.Styling = constants.StyledComponents
+	revalidate := 3600
+	config.SegmentConfig = constants.SegmentConfig{Runtime: "edge", Revalidate: &revalidate}
+	data = newPageData("blog", "BlogPage", config, false)
+	assert.True(t, data.UseClient)
+	assert.True(t, data.Segment.IsZero())
+	content, err = generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(content, "'use client';\n\nimport styled from 'styled-components';\n\nconst Wrapper = styled.div`"))
+	assert.Contains(t, content, "    <Wrapper>\n      <h1>BlogPage</h1>")
+	assert.NotContains(t, content, "export const")
+
+	config = &constants.Config{Router: constants.PagesRouter, Language: constants.Typescript, ComponentStyle: constants.Const, Styling: constants.Tailwind}
+	data = newPageData("blog", "BlogPage", config, false)
+	assert.Empty(t, data.StylesFile)
+	content, err = generateFileContent("page", data)
+	assert.NoError(t, err)
+	assert.Contains(t, content, "<div className=\"flex flex-col gap-4 p-8\">\n      <h1 className=\"text-2xl font-bold\">BlogPage</h1>")
+}
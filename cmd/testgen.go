@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+)
+
+// testsDir is the folder mirroring the routes when tests are not colocated
+const testsDir = "__tests__"
+
+// TestData holds the dynamic data for the unit test and e2e spec templates
+type TestData struct {
+	ComponentName string
+	Framework     string
+	Language      constants.LanguageType
+	// Import is the path of the page relative to the unit test, e.g. "./page"
+	Import string
+	// URL is the page URL with the dynamic segments filled with sample values
+	URL string
+	// SampleParams is a params object literal with sample values, e.g. "{ slug: 'sample-slug' }"
+	SampleParams string
+	AsyncParams  bool
+	// Async is set for async components, which are awaited, and Fetch for the ones fetching their data
+	Async bool
+	Fetch bool
+	// Suspends is set for client pages unwrapping their params with use(), which render once they resolve
+	Suspends bool
+	Data     string
+}
+
+// scaffoldTests renders the unit test and the Playwright spec of a generated page.
+func scaffoldTests(config *constants.Config, pageNameInput string, pagePath string, page PageData) ([]GeneratedFile, error) {
+	unitPath := unitTestPath(config, pagePath)
+	importPath, _ := filepath.Rel(filepath.Dir(unitPath), strings.TrimSuffix(pagePath, filepath.Ext(pagePath)))
+	importPath = filepath.ToSlash(importPath)
+	if !strings.HasPrefix(importPath, "../") {
+		importPath = "./" + importPath
+	}
+
+	url := routeURL(config, pageNameInput)
+	data := TestData{
+		ComponentName: page.ComponentName,
+		Framework:     config.Testing.TestFramework(),
+		Language:      config.Language,
+		Import:        importPath,
+		URL:           sampleURL(url),
+		AsyncParams:   page.AsyncParams,
+		Async:         page.AsyncComponent(),
+		Fetch:         page.Async,
+		Suspends:      page.TakesParams() && page.UseClient && page.AsyncParams,
+		Data:          page.Data,
+	}
+	if page.TakesParams() {
+		data.SampleParams = sampleParams(page.Params)
+	}
+
+	unit, err := generateFileContent("test-unit", data)
+	if err != nil {
+		return nil, err
+	}
+	e2e, err := generateFileContent("test-e2e", data)
+	if err != nil {
+		return nil, err
+	}
+	return []GeneratedFile{
+		{Path: unitPath, Content: unit},
+		{Path: e2eSpecPath(config, url), Content: e2e},
+	}, nil
+}
+
+// unitTestPath returns where the unit test of a page lives: next to it, or in the __tests__ folder
+// mirroring the routes directory. Pages router tests are never colocated as every file there is a route.
+func unitTestPath(config *constants.Config, pagePath string) string {
+	name := strings.TrimSuffix(filepath.Base(pagePath), filepath.Ext(pagePath)) + ".test" + componentExtension(config)
+	if config.Testing.Colocated() && config.Router == constants.AppRouter {
+		return filepath.Join(filepath.Dir(pagePath), name)
+	}
+	rel, _ := filepath.Rel(config.RoutesDir(), filepath.Dir(pagePath))
+	return filepath.Join(testsDir, rel, name)
+}
+
+// e2eSpecPath returns where the Playwright spec of a URL lives, e.g. "e2e/blog/_slug_.spec.ts" for "/blog/[slug]".
+// Dynamic segments keep underscores around their name so they never share a spec with a static segment.
+func e2eSpecPath(config *constants.Config, url string) string {
+	var parts []string
+	for _, raw := range routes.SplitURL(url) {
+		if seg, err := routes.ParseSegment(raw); err == nil && seg.Dynamic {
+			raw = "_" + seg.Name + "_"
+		}
+		parts = append(parts, raw)
+	}
+	if len(parts) == 0 {
+		parts = []string{"home"}
+	}
+	return filepath.Join(config.Testing.E2EDirectory(), filepath.Join(parts...)+".spec"+scriptExtension(config))
+}
+
+// sampleValue returns a sample value for a dynamic param: "1" for ids, "sample-<name>" otherwise.
+func sampleValue(name string) string {
+	lower := strings.ToLower(name)
+	if lower == "id" || strings.HasSuffix(name, "Id") || strings.HasSuffix(lower, "_id") || strings.HasSuffix(lower, "-id") {
+		return "1"
+	}
+	return "sample-" + lower
+}
+
+// sampleURL fills the dynamic segments of a URL pattern with sample values.
+func sampleURL(url string) string {
+	parts := []string{}
+	for _, raw := range routes.SplitURL(url) {
+		if seg, err := routes.ParseSegment(raw); err == nil && seg.Dynamic {
+			raw = sampleValue(seg.Name)
+		}
+		parts = append(parts, raw)
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// sampleParams returns the params object literal with sample values, catch-all params being arrays.
+func sampleParams(params PageParams) string {
+	var fields []string
+	for _, param := range params {
+		key := propertyName(param.Name)
+		value := fmt.Sprintf("'%s'", sampleValue(param.Name))
+		if param.CatchAll {
+			value = "[" + value + "]"
+		}
+		fields = append(fields, key+": "+value)
+	}
+	return "{ " + strings.Join(fields, ", ") + " }"
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScaffoldTests(t *testing.T) {
+	useTemplateFs(t)
+	config := &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, NextVersion: "15.0.0"}
+
+	page := newPageData("(blog)/posts/[postId]/[...rest]", "PostPage", config, false)
+	page.Async = true
+	files, err := scaffoldTests(config, "(blog)/posts/[postId]/[...rest]", filepath.Join("app", "(blog)", "posts", "[postId]", "[...rest]", "page.tsx"), page)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	assert.Equal(t, filepath.Join("app", "(blog)", "posts", "[postId]", "[...rest]", "page.test.tsx"), files[0].Path)
+	assert.Equal(t, `import { render, screen } from '@testing-library/react';
+import PostPage from './page';
+
+describe('PostPage', () => {
+  it('renders the page', async () => {
+    // Server components fetch their data, stub it so the test stays offline
+    global.fetch = jest.fn().mockResolvedValue({ json: async () => ({}) }) as unknown as typeof fetch;
+    render(await PostPage({ params: Promise.resolve({ postId: '1', rest: ['sample-rest'] }), searchParams: Promise.resolve({}) }));
+    expect(screen.getByRole('heading', { name: 'PostPage' })).toBeDefined();
+  });
+});`, files[0].Content)
+
+	assert.Equal(t, filepath.Join("e2e", "posts", "_postId_", "_rest_.spec.ts"), files[1].Path)
+	assert.Contains(t, files[1].Content, "await page.goto('/posts/1/sample-rest');")
+
+	config = &constants.Config{
+		Router:   constants.PagesRouter,
+		Language: constants.Javascript,
+		Testing:  constants.TestingConfig{Framework: "vitest", E2EDir: "tests/e2e"},
+	}
+	page = newPageData("about", "AboutPage", config, false)
+	page.Data = "ssg"
+	files, err = scaffoldTests(config, "about", filepath.Join("pages", "about", "index.jsx"), page)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("__tests__", "about", "index.test.jsx"), files[0].Path)
+	assert.Contains(t, files[0].Content, "import { describe, expect, it } from 'vitest';\nimport AboutPage from '../../pages/about/index';")
+	assert.Contains(t, files[0].Content, "render(<AboutPage data={null} />);")
+	assert.NotContains(t, files[0].Content, "global.fetch")
+	assert.Equal(t, filepath.Join("tests", "e2e", "about.spec.js"), files[1].Path)
+
+	config = &constants.Config{
+		Router:   constants.AppRouter,
+		Language: constants.Javascript,
+		Testing:  constants.TestingConfig{Framework: "vitest"},
+	}
+	page = newPageData("feed", "FeedPage", config, false)
+	page.Async = true
+	files, err = scaffoldTests(config, "feed", filepath.Join("app", "feed", "page.jsx"), page)
+	assert.NoError(t, err)
+	assert.Contains(t, files[0].Content, "import { describe, expect, it, vi } from 'vitest';")
+	assert.Contains(t, files[0].Content, "global.fetch = vi.fn().mockResolvedValue({ json: async () => ({}) });\n    render(await FeedPage());")
+
+	// Dynamic pages get sample params, client pages render once use() resolves them
+	config = &constants.Config{Router: constants.AppRouter, Language: constants.Typescript, NextVersion: "14.2.0"}
+	page = newPageData("blog/[slug]", "BlogPage", config, false)
+	files, err = scaffoldTests(config, "blog/[slug]", filepath.Join("app", "blog", "[slug]", "page.tsx"), page)
+	assert.NoError(t, err)
+	assert.Contains(t, files[0].Content, "it('renders the page', () => {\n    render(<BlogPage params={{ slug: 'sample-slug' }} searchParams={{}} />);")
+
+	config.NextVersion = "15.0.0"
+	page = newPageData("blog/[slug]", "BlogPage", config, true)
+	files, err = scaffoldTests(config, "blog/[slug]", filepath.Join("app", "blog", "[slug]", "page.tsx"), page)
+	assert.NoError(t, err)
+	assert.Contains(t, files[0].Content, "render(<BlogPage params={Promise.resolve({ slug: 'sample-slug' })} searchParams={Promise.resolve({})} />);")
+	assert.Contains(t, files[0].Content, "expect(await screen.findByRole('heading', { name: 'BlogPage' })).toBeDefined();")
+	assert.NotContains(t, files[0].Content, "global.fetch")
+
+	assert.Equal(t, filepath.Join("e2e", "home.spec.ts"), e2eSpecPath(&constants.Config{}, "/"))
+	assert.NotEqual(t, e2eSpecPath(&constants.Config{}, "/blog/[slug]"), e2eSpecPath(&constants.Config{}, "/blog/slug"))
+}
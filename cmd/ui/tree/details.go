@@ -0,0 +1,134 @@
+package treeui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/afero"
+)
+
+var (
+	stylePane = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(accentColor).
+			Padding(0, 1)
+	styleLabel   = lipgloss.NewStyle().Foreground(accentColor)
+	styleMuted   = lipgloss.NewStyle().Faint(true)
+	styleKeyword = lipgloss.NewStyle().Foreground(lipgloss.Color("#c678dd"))
+	styleString  = lipgloss.NewStyle().Foreground(lipgloss.Color("#98c379"))
+	styleComment = lipgloss.NewStyle().Foreground(lipgloss.Color("#7f848e")).Italic(true)
+	styleTag     = lipgloss.NewStyle().Foreground(lipgloss.Color("#e06c75"))
+)
+
+// tokenPattern splits a line of JS/TS into comments, strings, JSX tags, words and everything else.
+var tokenPattern = regexp.MustCompile("//.*|/\\*.*?\\*/|'(?:[^'\\\\]|\\\\.)*'|\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`|</?[A-Za-z][\\w.]*|[A-Za-z_$][\\w$]*|[^A-Za-z_$/'\"`<]+|.")
+
+var keywords = map[string]bool{
+	"import": true, "export": true, "default": true, "from": true, "function": true, "const": true, "let": true,
+	"var": true, "return": true, "async": true, "await": true, "if": true, "else": true, "type": true,
+	"interface": true, "new": true, "null": true, "undefined": true, "true": true, "false": true,
+}
+
+// highlight colours a single line of source code.
+func highlight(line string) string {
+	var b strings.Builder
+	for _, token := range tokenPattern.FindAllString(line, -1) {
+		switch {
+		case strings.HasPrefix(token, "//") || strings.HasPrefix(token, "/*"):
+			b.WriteString(styleComment.Render(token))
+		case strings.HasPrefix(token, "'") || strings.HasPrefix(token, "\"") || strings.HasPrefix(token, "`"):
+			b.WriteString(styleString.Render(token))
+		case strings.HasPrefix(token, "<") && len(token) > 1:
+			b.WriteString(styleTag.Render(token))
+		case keywords[token]:
+			b.WriteString(styleKeyword.Render(token))
+		default:
+			b.WriteString(token)
+		}
+	}
+	return b.String()
+}
+
+// truncate cuts a line to the given number of columns.
+func truncate(line string, width int) string {
+	if runes := []rune(line); len(runes) > width {
+		if width < 1 {
+			return ""
+		}
+		return string(runes[:width-1]) + "…"
+	}
+	return line
+}
+
+// detailsView renders the pane describing the node at path.
+func (m Model) detailsView(path string, width, height int) string {
+	inner := width - 4 // border and padding
+	var lines []string
+	field := func(label, value string) {
+		lines = append(lines, styleLabel.Render(label+": ")+truncate(value, inner-len(label)-2))
+	}
+
+	route, ok := m.meta[path]
+	if !ok {
+		lines = append(lines, styleLabel.Render("/"+path), "", styleMuted.Render("No page or route handler in this folder."))
+		return stylePane.Width(width - 2).Height(height - 2).Render(strings.Join(lines, "\n"))
+	}
+
+	field("URL", route.URL)
+	field("Kind", string(route.Kind))
+	if len(route.Params) > 0 {
+		field("Params", strings.Join(route.Params, ", "))
+	}
+	field("File", route.File)
+
+	details, err := routes.Inspect(m.fs, m.config, route)
+	if err != nil {
+		lines = append(lines, styleError.Render(err.Error()))
+		return stylePane.Width(width - 2).Height(height - 2).Render(strings.Join(lines, "\n"))
+	}
+	if route.Kind == routes.PageKind {
+		component := "server component"
+		if details.Client {
+			component = "client component ('use client')"
+		}
+		field("Component", component)
+	}
+	if len(details.Layouts) > 0 {
+		field("Layouts", strings.Join(details.Layouts, " → "))
+	}
+	if details.Metadata != "" {
+		field("Metadata", details.Metadata)
+	}
+	if len(details.SegmentConfig) > 0 {
+		keys := make([]string, 0, len(details.SegmentConfig))
+		for key := range details.SegmentConfig {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			field(key, details.SegmentConfig[key])
+		}
+	}
+
+	// Preview of the first lines that still fit
+	content, _ := afero.ReadFile(m.fs, route.File)
+	available := height - 2 - len(lines) - 2
+	if available > 0 && len(content) > 0 {
+		lines = append(lines, "", styleMuted.Render(strings.Repeat("─", inner)))
+		source := strings.Split(strings.ReplaceAll(string(content), "\t", "  "), "\n")
+		if len(source) > available {
+			source = source[:available]
+		}
+		gutter := len(fmt.Sprint(len(source)))
+		for i, line := range source {
+			number := styleMuted.Render(fmt.Sprintf("%*d ", gutter, i+1))
+			lines = append(lines, number+highlight(truncate(line, inner-gutter-1)))
+		}
+	}
+
+	return stylePane.Width(width - 2).Height(height - 2).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,115 @@
+package treeui
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	tree "github.com/savannahostrowski/tree-bubble"
+)
+
+// filter is a parsed search query: fuzzy terms plus "key:value" predicates over node metadata.
+type filter struct {
+	terms      []string
+	predicates map[string]string
+}
+
+// Supported predicate keys, e.g. "kind:api" or "dynamic:true".
+var predicateKeys = map[string]bool{"kind": true, "dynamic": true}
+
+func parseFilter(query string) filter {
+	f := filter{predicates: make(map[string]string)}
+	for _, field := range strings.Fields(query) {
+		if key, value, ok := strings.Cut(field, ":"); ok && predicateKeys[strings.ToLower(key)] {
+			f.predicates[strings.ToLower(key)] = strings.ToLower(value)
+			continue
+		}
+		f.terms = append(f.terms, strings.ToLower(field))
+	}
+	return f
+}
+
+func (f filter) empty() bool {
+	return len(f.terms) == 0 && len(f.predicates) == 0
+}
+
+// matches reports whether the node at path satisfies every term and predicate.
+func (f filter) matches(path string, node tree.Node, route *routes.Route) bool {
+	for key, value := range f.predicates {
+		switch key {
+		case "kind":
+			if route == nil || string(route.Kind) != value {
+				return false
+			}
+		case "dynamic":
+			want, err := strconv.ParseBool(value)
+			if err != nil || route == nil || route.Dynamic() != want {
+				return false
+			}
+		}
+	}
+
+	haystacks := []string{"/" + path, node.Desc}
+	if route != nil {
+		haystacks = append(haystacks, route.URL, route.File)
+	}
+	for _, term := range f.terms {
+		matched := false
+		for _, haystack := range haystacks {
+			if fuzzyMatch(term, haystack) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// fuzzyMatch reports whether the pattern's characters appear in text in order, ignoring case.
+func fuzzyMatch(pattern, text string) bool {
+	runes := []rune(pattern)
+	i := 0
+	for _, r := range text {
+		if i == len(runes) {
+			break
+		}
+		if unicode.ToLower(r) == runes[i] {
+			i++
+		}
+	}
+	return i == len(runes)
+}
+
+// filterNodes keeps the matching nodes and their ancestors, collapsing branches without matches.
+// It also returns the paths of the matching nodes in render order.
+func filterNodes(nodes []tree.Node, parent string, root bool, f filter, meta map[string]routes.Route) ([]tree.Node, []string) {
+	var kept []tree.Node
+	var matches []string
+	for _, node := range nodes {
+		path := ""
+		if !root {
+			path = joinPath(parent, node.Value)
+		}
+
+		var route *routes.Route
+		if r, ok := meta[path]; ok {
+			route = &r
+		}
+		matched := f.matches(path, node, route)
+		children, childMatches := filterNodes(node.Children, path, false, f, meta)
+
+		if matched {
+			matches = append(matches, path)
+		}
+		matches = append(matches, childMatches...)
+		if matched || len(children) > 0 || root {
+			node.Children = children
+			kept = append(kept, node)
+		}
+	}
+	return kept, matches
+}
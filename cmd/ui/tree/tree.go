@@ -1,10 +1,16 @@
 package treeui
 
 import (
+	"fmt"
 	"os"
-	"path/filepath"
+	"os/exec"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/term"
@@ -16,17 +22,69 @@ var accentColor = lipgloss.Color("#f3bd72")
 
 var (
 	styleDoc = lipgloss.NewStyle().
-		PaddingTop(2).
-		PaddingLeft(2)
+			PaddingTop(2).
+			PaddingLeft(2)
+	styleStatus = lipgloss.NewStyle().Foreground(accentColor)
+	styleError  = lipgloss.NewStyle().Foreground(lipgloss.Color("#ff6b6b"))
 )
 
+// Actions are the route operations the view can trigger. Paths are 'add' style paths ("" for the root).
+type Actions struct {
+	Add    func(parent, name string) error
+	Delete func(path string) error
+	Rename func(path, newPath string) error
+	Open   func(path string) (*exec.Cmd, error)
+	Reload func() Snapshot
+}
+
+// Snapshot is the scanned route tree along with the route behind each node path.
+type Snapshot struct {
+	Root   tree.Node
+	Routes map[string]routes.Route
+}
+
+type mode int
+
+const (
+	browseMode mode = iota
+	addMode
+	renameMode
+	deleteMode
+	filterMode
+)
+
+// editorFinishedMsg is sent when the external editor exits.
+type editorFinishedMsg struct{ err error }
+
 type Model struct {
-	tree   tree.Model
-	width  int
-	height int
+	tree    tree.Model
+	nodes   []tree.Node
+	meta    map[string]routes.Route
+	fs      afero.Fs
+	config  *constants.Config
+	details bool
+
+	interval        time.Duration
+	lastFingerprint string
+	highlights      map[string]time.Time
+
+	actions *Actions
+	mode    mode
+	input   textinput.Model
+	query   string
+	matches []string
+	match   int
+	target  string
+	status  string
+	failed  bool
+	width   int
+	height  int
 }
 
 func (m Model) Init() tea.Cmd {
+	if m.interval > 0 {
+		return m.tick()
+	}
 	return nil
 }
 
@@ -35,10 +93,49 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		m.tree.SetSize(m.treeWidth(), msg.Height-6)
+		return m, nil
+	case tickMsg:
+		return m.poll(time.Time(msg)), m.tick()
+	case editorFinishedMsg:
+		if msg.err != nil {
+			m.setStatus(fmt.Sprintf("Editor exited with an error: %v", msg.err), true)
+		}
+		m.reload()
+		return m, nil
 	case tea.KeyMsg:
+		if m.mode != browseMode {
+			return m.updatePrompt(msg)
+		}
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
+		case "/":
+			m.mode = filterMode
+			m.input.SetValue(m.query)
+			m.input.CursorEnd()
+			m.input.Prompt = "/"
+			m.input.Focus()
+			return m, textinput.Blink
+		case "n", "N":
+			if len(m.matches) > 0 {
+				step := 1
+				if msg.String() == "N" {
+					step = len(m.matches) - 1
+				}
+				m.jumpTo((m.match + step) % len(m.matches))
+				return m, nil
+			}
+		case "esc":
+			if m.query != "" {
+				m.applyFilter("")
+				return m, nil
+			}
+		}
+		if m.actions != nil {
+			if model, cmd, handled := m.handleAction(msg.String()); handled {
+				return model, cmd
+			}
 		}
 	}
 	var cmd tea.Cmd
@@ -46,99 +143,401 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// handleAction starts the route operation bound to the key, if any.
+func (m Model) handleAction(key string) (tea.Model, tea.Cmd, bool) {
+	path, ok := m.SelectedPath()
+	if !ok {
+		return m, nil, false
+	}
+
+	switch key {
+	case "a":
+		m.mode = addMode
+		m.target = path
+		m.input.SetValue("")
+		m.input.Prompt = fmt.Sprintf("New route under /%s: ", path)
+		m.input.Focus()
+		return m, textinput.Blink, true
+	case "r":
+		if path == "" {
+			m.setStatus("The root page cannot be renamed.", true)
+			return m, nil, true
+		}
+		m.mode = renameMode
+		m.target = path
+		m.input.SetValue(path)
+		m.input.CursorEnd()
+		m.input.Prompt = "Rename to: "
+		m.input.Focus()
+		return m, textinput.Blink, true
+	case "d":
+		m.mode = deleteMode
+		m.target = path
+		return m, nil, true
+	case "o":
+		cmd, err := m.actions.Open(path)
+		if err != nil {
+			m.setStatus(err.Error(), true)
+			return m, nil, true
+		}
+		return m, tea.ExecProcess(cmd, func(err error) tea.Msg { return editorFinishedMsg{err} }), true
+	}
+	return m, nil, false
+}
+
+// updatePrompt handles keys while an add, rename or delete prompt is open.
+func (m Model) updatePrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.mode == deleteMode {
+		if strings.EqualFold(msg.String(), "y") {
+			if err := m.actions.Delete(m.target); err != nil {
+				m.setStatus(err.Error(), true)
+			} else {
+				m.setStatus(fmt.Sprintf("Deleted /%s", m.target), false)
+			}
+			m.reload()
+		} else {
+			m.setStatus("Delete cancelled.", false)
+		}
+		m.mode = browseMode
+		return m, nil
+	}
+
+	if m.mode == filterMode {
+		switch msg.String() {
+		case "esc", "ctrl+c":
+			m.applyFilter("")
+			m.mode = browseMode
+			m.input.Blur()
+			return m, nil
+		case "enter":
+			m.mode = browseMode
+			m.input.Blur()
+			return m, nil
+		}
+		// Filter as you type
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		m.applyFilter(m.input.Value())
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.mode = browseMode
+		m.input.Blur()
+		m.setStatus("", false)
+		return m, nil
+	case "enter":
+		value := strings.Trim(strings.TrimSpace(m.input.Value()), "/")
+		var err error
+		switch {
+		case value == "":
+			err = fmt.Errorf("route name cannot be empty")
+		case m.mode == addMode:
+			if err = m.actions.Add(m.target, value); err == nil {
+				m.setStatus(fmt.Sprintf("Added /%s", joinPath(m.target, value)), false)
+			}
+		case m.mode == renameMode:
+			if err = m.actions.Rename(m.target, value); err == nil {
+				m.setStatus(fmt.Sprintf("Renamed /%s to /%s", m.target, value), false)
+			}
+		}
+		if err != nil {
+			m.setStatus(err.Error(), true)
+		}
+		m.mode = browseMode
+		m.input.Blur()
+		m.reload()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m *Model) setStatus(status string, failed bool) {
+	m.status = status
+	m.failed = failed
+}
+
+// reload rebuilds the tree in place, keeping the filter and the selected node.
+func (m *Model) reload() {
+	var snapshot Snapshot
+	switch {
+	case m.actions != nil && m.actions.Reload != nil:
+		snapshot = m.actions.Reload()
+	case m.fs != nil:
+		snapshot = Load(m.fs, m.config)
+	default:
+		return
+	}
+	m.nodes = []tree.Node{snapshot.Root}
+	m.meta = snapshot.Routes
+	m.keepSelection(m.refresh)
+}
+
+// keepSelection runs update and moves the cursor back to the node selected before, when it still exists.
+func (m *Model) keepSelection(update func()) {
+	selected, ok := m.SelectedPath()
+	update()
+	if ok {
+		for index, e := range flatten(m.tree.Nodes(), "", true) {
+			if e.path == selected {
+				m.tree.SetCursor(index)
+				return
+			}
+		}
+	}
+	if count := m.tree.NumberOfNodes(); m.tree.Cursor() >= count {
+		m.tree.SetCursor(count - 1)
+	}
+}
+
+// applyFilter filters the tree with the query and jumps to the first match.
+func (m *Model) applyFilter(query string) {
+	m.query = strings.TrimSpace(query)
+	m.refresh()
+	m.tree.SetCursor(0)
+	if len(m.matches) > 0 {
+		m.jumpTo(0)
+	}
+}
+
+// refresh re-renders the tree from the full node set, the current query and the highlights.
+func (m *Model) refresh() {
+	nodes := m.nodes
+	f := parseFilter(m.query)
+	if f.empty() {
+		m.matches = nil
+	} else {
+		nodes, m.matches = filterNodes(m.nodes, "", true, f, m.meta)
+		if m.match >= len(m.matches) {
+			m.match = 0
+		}
+	}
+	if len(m.highlights) > 0 {
+		nodes = m.decorate(nodes, "", true)
+	}
+	m.tree.SetNodes(nodes)
+}
+
+// decorate marks the highlighted nodes. Only Desc is touched, as Value makes up the node path.
+func (m Model) decorate(nodes []tree.Node, parent string, root bool) []tree.Node {
+	decorated := make([]tree.Node, len(nodes))
+	for i, node := range nodes {
+		path := ""
+		if !root {
+			path = joinPath(parent, node.Value)
+		}
+		if _, ok := m.highlights[path]; ok {
+			node.Desc = styleStatus.Render(node.Desc + " ●")
+		}
+		node.Children = m.decorate(node.Children, path, false)
+		decorated[i] = node
+	}
+	return decorated
+}
+
+// jumpTo moves the cursor to the i-th match.
+func (m *Model) jumpTo(i int) {
+	m.match = i
+	for index, e := range flatten(m.tree.Nodes(), "", true) {
+		if e.path == m.matches[i] {
+			m.tree.SetCursor(index)
+			return
+		}
+	}
+}
+
+// SelectedPath returns the 'add' style path of the node under the cursor.
+func (m Model) SelectedPath() (string, bool) {
+	entries := flatten(m.tree.Nodes(), "", true)
+	cursor := m.tree.Cursor()
+	if cursor < 0 || cursor >= len(entries) {
+		return "", false
+	}
+	return entries[cursor].path, true
+}
+
+type entry struct {
+	node tree.Node
+	path string
+}
+
+// flatten lists the nodes in render order along with their paths; the root node maps to "".
+func flatten(nodes []tree.Node, parent string, root bool) []entry {
+	var entries []entry
+	for _, node := range nodes {
+		path := ""
+		if !root {
+			path = joinPath(parent, node.Value)
+		}
+		entries = append(entries, entry{node: node, path: path})
+		entries = append(entries, flatten(node.Children, path, false)...)
+	}
+	return entries
+}
+
+func joinPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
+
 func (m Model) View() string {
 	if m.width == 0 {
 		return "loading..."
 	}
-	return styleDoc.Render(m.tree.View())
+
+	var footer string
+	switch m.mode {
+	case addMode, renameMode:
+		footer = m.input.View()
+	case deleteMode:
+		footer = styleError.Render(fmt.Sprintf("Delete /%s and its special files? (y/N)", m.target))
+	case filterMode:
+		footer = lipgloss.JoinVertical(lipgloss.Left, m.input.View(), lipgloss.NewStyle().Faint(true).Render(
+			fmt.Sprintf("%d match(es) • fuzzy over paths and files • kind:page|api • dynamic:true|false • enter keep • esc clear", len(m.matches))))
+	default:
+		if m.query != "" {
+			footer = styleStatus.Render(fmt.Sprintf("/%s  %d match(es) • n/N next/previous • esc clear", m.query, len(m.matches)))
+		}
+		if m.status != "" {
+			status := styleStatus.Render(m.status)
+			if m.failed {
+				status = styleError.Render(m.status)
+			}
+			footer = lipgloss.JoinVertical(lipgloss.Left, footer, status)
+		}
+		help := "/ search • q quit"
+		if m.actions != nil {
+			help = "a add • d delete • r rename • o open • " + help
+		}
+		footer = lipgloss.JoinVertical(lipgloss.Left, footer, lipgloss.NewStyle().Faint(true).Render(help))
+	}
+	body := m.tree.View()
+	if m.showDetails() {
+		path, _ := m.SelectedPath()
+		treeView := lipgloss.NewStyle().Width(m.treeWidth()).Render(body)
+		body = lipgloss.JoinHorizontal(lipgloss.Top, treeView, m.detailsView(path, m.width-m.treeWidth()-4, m.height-6))
+	}
+	if footer == "" {
+		return styleDoc.Render(body)
+	}
+	return styleDoc.Render(lipgloss.JoinVertical(lipgloss.Left, body, footer))
+}
+
+// showDetails reports whether the detail pane is enabled and the terminal is wide enough for it.
+func (m Model) showDetails() bool {
+	return m.details && m.width >= 80
+}
+
+// treeWidth is the width left to the tree, half of the screen when the detail pane is shown.
+func (m Model) treeWidth() int {
+	if m.showDetails() {
+		return m.width / 2
+	}
+	return m.width
 }
 
 func New(nodes []tree.Node) Model {
 	w, h, _ := term.GetSize(os.Stdout.Fd())
-	m := tree.New(nodes, w, h)
+	m := tree.New(nodes, w, h-4)
 
 	m.Styles.Selected = lipgloss.NewStyle().Foreground(accentColor)
 	m.Styles.Shapes = lipgloss.NewStyle().Foreground(accentColor)
 
-	return Model{tree: m}
+	input := textinput.New()
+	input.PromptStyle = lipgloss.NewStyle().Foreground(accentColor)
+
+	return Model{tree: m, nodes: nodes, input: input}
 }
 
-func convertToRoute(filePath string) string {
-	route := strings.TrimSuffix(filePath, filepath.Ext(filePath))
-	route = strings.ReplaceAll(route, "\\", "/")
-	route = strings.ReplaceAll(route, "/page", "")
-	route = strings.ReplaceAll(route, "/index", "")
-	if route == "" {
-		return "/"
-	}
-	return route
+// WithDetails enables the detail pane, which reads route files from fs.
+func (m Model) WithDetails(fs afero.Fs, config *constants.Config) Model {
+	m.fs = fs
+	m.config = config
+	m.details = true
+	return m
 }
 
-// Building a hierarchical tree representation of the route structure starting with the `startPath`.
-func BuildRouteTree(fs afero.Fs, startPath string) tree.Node {
-	nodeMap := make(map[string]*tree.Node)
+// WithWatch polls the routes directory every interval and rebuilds the tree when files are
+// added, removed or renamed, briefly highlighting the nodes that changed.
+func (m Model) WithWatch(fs afero.Fs, config *constants.Config, interval time.Duration) Model {
+	m.fs = fs
+	m.config = config
+	m.interval = interval
+	m.highlights = make(map[string]time.Time)
+	m.lastFingerprint = m.fingerprint()
+	return m
+}
 
-	_ = afero.Walk(fs, startPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil || info.IsDir() {
-			return nil
-		}
-		if strings.HasSuffix(info.Name(), ".tsx") || strings.HasSuffix(info.Name(), ".jsx") || strings.HasSuffix(info.Name(), ".js") {
-			relPath := strings.TrimPrefix(path, startPath)
-			routePath := convertToRoute(relPath)
-			parts := strings.Split(strings.Trim(routePath, "/"), "/")
+// WithRoutes attaches the route behind each node path, used by the filter predicates.
+func (m Model) WithRoutes(meta map[string]routes.Route) Model {
+	m.meta = meta
+	return m
+}
 
-			var fullPath string
-			for i, part := range parts {
-				if i == 0 {
-					fullPath = part
-				} else {
-					fullPath = filepath.Join(fullPath, part)
-				}
-				if _, exists := nodeMap[fullPath]; !exists {
-					relFile := strings.TrimPrefix(path, string(filepath.Separator))
-					nodeMap[fullPath] = &tree.Node{
-						Value:    part,
-						Desc:     relFile,
-						Children: []tree.Node{},
-					}
-				}
+// WithActions enables route management from the view.
+func (m Model) WithActions(actions Actions) Model {
+	m.actions = &actions
+	return m
+}
+
+type buildNode struct {
+	value    string
+	desc     string
+	children map[string]*buildNode
+}
 
-				// Link to the parent
-				if i > 0 {
-					parentPath := filepath.Join(parts[:i]...)
-					parent := nodeMap[parentPath]
-					child := nodeMap[fullPath]
-					alreadyChild := false
-					for _, c := range parent.Children {
-						if c.Value == child.Value {
-							alreadyChild = true
-							break
-						}
-					}
-					if !alreadyChild {
-						parent.Children = append(parent.Children, *child)
-					}
+// Building a hierarchical tree representation of the route structure of the configured router.
+func BuildRouteTree(fs afero.Fs, config *constants.Config) tree.Node {
+	return Load(fs, config).Root
+}
+
+// Load scans the routes and builds the tree along with the route behind each node path.
+func Load(fs afero.Fs, config *constants.Config) Snapshot {
+	root := &buildNode{value: config.RoutesDir(), children: make(map[string]*buildNode)}
+	meta := make(map[string]routes.Route)
+
+	found, _ := routes.Scan(fs, config)
+	for _, route := range found {
+		node := root
+		location := route.Location(config)
+		if _, exists := meta[location]; !exists {
+			meta[location] = route
+		}
+		if location != "" {
+			for _, part := range strings.Split(location, "/") {
+				child, exists := node.children[part]
+				if !exists {
+					child = &buildNode{value: part, children: make(map[string]*buildNode)}
+					node.children[part] = child
 				}
+				node = child
 			}
 		}
-		return nil
-	})
+		if node.desc == "" {
+			node.desc = route.File
+		}
+	}
 
-	rootValue := strings.TrimPrefix(startPath, string(filepath.Separator))
-	root, ok := nodeMap[rootValue]
-	if !ok {
-		root = &tree.Node{Value: rootValue}
-	} else {
-		root.Value = rootValue
+	return Snapshot{Root: root.toNode(), Routes: meta}
+}
+
+// toNode converts the build tree into tree-bubble nodes with children sorted by name.
+func (n *buildNode) toNode() tree.Node {
+	names := make([]string, 0, len(n.children))
+	for name := range n.children {
+		names = append(names, name)
 	}
+	sort.Strings(names)
 
-	// Collect all root-level children
-	var rootChildren []tree.Node
-	for key, node := range nodeMap {
-		if !strings.Contains(key, string(filepath.Separator)) {
-			rootChildren = append(rootChildren, *node)
-		}
+	node := tree.Node{Value: n.value, Desc: n.desc, Children: []tree.Node{}}
+	for _, name := range names {
+		node.Children = append(node.Children, n.children[name].toNode())
 	}
-	root.Children = rootChildren
-	return *root
+	return node
 }
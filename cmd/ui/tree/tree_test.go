@@ -0,0 +1,145 @@
+package treeui
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	tea "github.com/charmbracelet/bubbletea"
+	tree "github.com/savannahostrowski/tree-bubble"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRouteTree(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for _, file := range []string{"app/page.tsx", "app/docs/a/b/c/page.tsx", "app/docs/page.tsx"} {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(file), []byte(""), 0644))
+	}
+
+	root := BuildRouteTree(fs, &constants.Config{Router: constants.AppRouter})
+	assert.Equal(t, "app", root.Value)
+	assert.Equal(t, filepath.Join("app", "page.tsx"), root.Desc)
+
+	var paths []string
+	for _, e := range flatten([]tree.Node{root}, "", true) {
+		paths = append(paths, e.path)
+	}
+	assert.Equal(t, []string{"", "docs", "docs/a", "docs/a/b", "docs/a/b/c"}, paths)
+}
+
+func TestAddAction(t *testing.T) {
+	var parent, name string
+	nodes := []tree.Node{{Value: "app", Children: []tree.Node{{Value: "blog"}}}}
+	m := New(nodes).WithActions(Actions{
+		Add: func(p, n string) error {
+			parent, name = p, n
+			return nil
+		},
+		Reload: func() Snapshot { return Snapshot{Root: nodes[0]} },
+	})
+
+	for _, msg := range []tea.Msg{
+		tea.KeyMsg{Type: tea.KeyDown},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")},
+		tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("archive")},
+		tea.KeyMsg{Type: tea.KeyEnter},
+	} {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+
+	assert.Equal(t, "blog", parent)
+	assert.Equal(t, "archive", name)
+	assert.Equal(t, "Added /blog/archive", m.status)
+}
+
+func TestFilter(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for _, file := range []string{
+		"app/page.tsx",
+		"app/blog/page.tsx",
+		"app/blog/[slug]/page.tsx",
+		"app/settings/profile/page.tsx",
+		"app/api/users/route.ts",
+	} {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(file), []byte(""), 0644))
+	}
+	snapshot := Load(fs, &constants.Config{Router: constants.AppRouter})
+	m := New([]tree.Node{snapshot.Root}).WithRoutes(snapshot.Routes)
+
+	m.applyFilter("stprf")
+	assert.Equal(t, []string{"settings/profile"}, m.matches)
+	path, _ := m.SelectedPath()
+	assert.Equal(t, "settings/profile", path)
+
+	// Non-matching branches are collapsed
+	var visible []string
+	for _, e := range flatten(m.tree.Nodes(), "", true) {
+		visible = append(visible, e.path)
+	}
+	assert.Equal(t, []string{"", "settings", "settings/profile"}, visible)
+
+	m.applyFilter("dynamic:true")
+	assert.Equal(t, []string{"blog/[slug]"}, m.matches)
+
+	m.applyFilter("kind:page blog")
+	assert.Equal(t, []string{"blog", "blog/[slug]"}, m.matches)
+
+	// n cycles through the matches
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	path, _ = updated.(Model).SelectedPath()
+	assert.Equal(t, "blog/[slug]", path)
+
+	m.applyFilter("")
+	assert.Len(t, flatten(m.tree.Nodes(), "", true), 7)
+}
+
+func TestDetailsPane(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join("app", "layout.tsx"), []byte(""), 0644))
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join("app", "blog", "[slug]", "page.tsx"), []byte("export const runtime = 'edge';\nexport default function SlugPage() {}\n"), 0644))
+
+	config := &constants.Config{Router: constants.AppRouter}
+	snapshot := Load(fs, config)
+	m := New([]tree.Node{snapshot.Root}).WithRoutes(snapshot.Routes).WithDetails(fs, config)
+
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 40})
+	m = updated.(Model)
+	m.tree.SetCursor(2)
+
+	view := m.View()
+	assert.Contains(t, view, "/blog/[slug]")
+	assert.Contains(t, view, "slug")
+	assert.Contains(t, view, "server component")
+	assert.Contains(t, view, filepath.Join("app", "layout.tsx"))
+	assert.Contains(t, view, "'edge'")
+	assert.Contains(t, view, "SlugPage")
+}
+
+func TestWatch(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	config := &constants.Config{Router: constants.AppRouter}
+	for _, file := range []string{"app/page.tsx", "app/docs/page.tsx"} {
+		assert.NoError(t, afero.WriteFile(fs, filepath.FromSlash(file), []byte(""), 0644))
+	}
+	snapshot := Load(fs, config)
+	m := New([]tree.Node{snapshot.Root}).WithRoutes(snapshot.Routes).WithWatch(fs, config, time.Second)
+	m.tree.SetCursor(1)
+
+	// A new route sorted before the selected one
+	assert.NoError(t, afero.WriteFile(fs, filepath.Join("app", "about", "page.tsx"), []byte(""), 0644))
+	now := time.Now()
+	m = m.poll(now)
+
+	assert.Contains(t, m.highlights, "about")
+	path, _ := m.SelectedPath()
+	assert.Equal(t, "docs", path, "selection is kept")
+	assert.Contains(t, m.tree.Nodes()[0].Children[0].Desc, "●")
+
+	// Highlights fade out
+	m = m.poll(now.Add(3 * time.Second))
+	assert.Empty(t, m.highlights)
+	assert.NotContains(t, m.tree.Nodes()[0].Children[0].Desc, "●")
+}
@@ -0,0 +1,86 @@
+package treeui
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/afero"
+)
+
+// How long changed nodes stay highlighted after a rebuild.
+const highlightDuration = 2 * time.Second
+
+// tickMsg triggers a poll of the routes directory.
+type tickMsg time.Time
+
+func (m Model) tick() tea.Cmd {
+	return tea.Tick(m.interval, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+// fingerprint lists every path under the routes directory, so additions, removals and renames change it.
+func (m Model) fingerprint() string {
+	var paths []string
+	_ = afero.Walk(m.fs, m.config.RoutesDir(), func(path string, info os.FileInfo, err error) error {
+		if err == nil {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	sort.Strings(paths)
+	return strings.Join(paths, "\n")
+}
+
+// poll rebuilds the tree when the routes directory changed and expires old highlights.
+func (m Model) poll(now time.Time) Model {
+	refresh := false
+	for path, until := range m.highlights {
+		if now.After(until) {
+			delete(m.highlights, path)
+			refresh = true
+		}
+	}
+
+	if fingerprint := m.fingerprint(); fingerprint != m.lastFingerprint {
+		m.lastFingerprint = fingerprint
+		previous := m.meta
+		m.reload()
+		for _, path := range changedPaths(previous, m.meta) {
+			m.highlights[path] = now.Add(highlightDuration)
+		}
+		refresh = true
+	}
+
+	if refresh {
+		m.keepSelection(m.refresh)
+	}
+	return m
+}
+
+// changedPaths returns the node paths whose route was added or whose files changed between two snapshots.
+func changedPaths(previous, current map[string]routes.Route) []string {
+	var changed []string
+	for path, route := range current {
+		old, existed := previous[path]
+		if !existed || old.File != route.File || !sameSpecial(old.Special, route.Special) {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func sameSpecial(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, file := range a {
+		if b[name] != file {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,293 @@
+package wizardui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var accentColor = lipgloss.Color("#f3bd72")
+
+var (
+	styleDoc = lipgloss.NewStyle().
+			PaddingTop(1).
+			PaddingLeft(2)
+	styleTitle   = lipgloss.NewStyle().Bold(true).Foreground(accentColor)
+	styleFocused = lipgloss.NewStyle().Foreground(accentColor)
+	styleMuted   = lipgloss.NewStyle().Faint(true)
+	stylePreview = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(accentColor).
+			Padding(0, 1)
+)
+
+// Choices are the answers collected by the wizard.
+type Choices struct {
+	Path      string
+	Special   []string
+	UseClient bool
+	Template  string
+}
+
+// Options configure what the wizard offers.
+type Options struct {
+	// Folders are suggested while typing the path (e.g. "blog/", "blog/[slug]/").
+	Folders []string
+	// Special are the special files that can be added; the section is hidden when empty.
+	Special []string
+	// AllowClient shows the 'use client' toggle.
+	AllowClient bool
+	// Templates are the page templates to choose from, the first one being the default.
+	Templates []string
+	// Preview renders the files the current choices would create.
+	Preview func(Choices) string
+}
+
+type rowKind int
+
+const (
+	pathRow rowKind = iota
+	specialRow
+	clientRow
+	templateRow
+	confirmRow
+)
+
+type row struct {
+	kind  rowKind
+	index int
+}
+
+type Model struct {
+	options   Options
+	path      textinput.Model
+	special   map[string]bool
+	useClient bool
+	template  int
+	rows      []row
+	cursor    int
+	confirmed bool
+	cancelled bool
+	width     int
+	height    int
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		current := m.rows[m.cursor]
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.cancelled = true
+			return m, tea.Quit
+		case "up", "shift+tab":
+			m.move(-1)
+			return m, nil
+		case "down", "tab":
+			// Tab completes the path while a suggestion is shown
+			if msg.String() == "tab" && current.kind == pathRow && m.path.CurrentSuggestion() != "" && m.path.CurrentSuggestion() != m.path.Value() {
+				break
+			}
+			m.move(1)
+			return m, nil
+		case "enter":
+			if current.kind == confirmRow {
+				if strings.Trim(m.path.Value(), "/ ") == "" {
+					m.cursor = 0
+					m.path.Focus()
+					return m, nil
+				}
+				m.confirmed = true
+				return m, tea.Quit
+			}
+			m.move(1)
+			return m, nil
+		case " ", "x":
+			if current.kind == specialRow {
+				name := m.options.Special[current.index]
+				m.special[name] = !m.special[name]
+				return m, nil
+			}
+			if current.kind == clientRow {
+				m.useClient = !m.useClient
+				return m, nil
+			}
+		case "left", "right":
+			if current.kind == templateRow && len(m.options.Templates) > 0 {
+				step := 1
+				if msg.String() == "left" {
+					step = len(m.options.Templates) - 1
+				}
+				m.template = (m.template + step) % len(m.options.Templates)
+				return m, nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.path, cmd = m.path.Update(msg)
+	return m, cmd
+}
+
+// move moves the cursor between rows, focusing the path input when it is reached.
+func (m *Model) move(step int) {
+	m.cursor = (m.cursor + step + len(m.rows)) % len(m.rows)
+	if m.rows[m.cursor].kind == pathRow {
+		m.path.Focus()
+	} else {
+		m.path.Blur()
+	}
+}
+
+// Choices returns the current answers.
+func (m Model) Choices() Choices {
+	choices := Choices{
+		Path:      strings.Trim(strings.TrimSpace(m.path.Value()), "/"),
+		UseClient: m.useClient,
+	}
+	for _, name := range m.options.Special {
+		if m.special[name] {
+			choices.Special = append(choices.Special, name)
+		}
+	}
+	if len(m.options.Templates) > 0 {
+		choices.Template = m.options.Templates[m.template]
+	}
+	return choices
+}
+
+// Result returns the answers and whether the user confirmed them.
+func (m Model) Result() (Choices, bool) {
+	return m.Choices(), m.confirmed && !m.cancelled
+}
+
+func (m Model) View() string {
+	if m.width == 0 {
+		return "loading..."
+	}
+
+	var b strings.Builder
+	b.WriteString(styleTitle.Render("Add a page") + "\n\n")
+	for i, r := range m.rows {
+		pointer := "  "
+		render := func(s string) string { return s }
+		if i == m.cursor {
+			pointer = styleFocused.Render("> ")
+			render = func(s string) string { return styleFocused.Render(s) }
+		}
+
+		switch r.kind {
+		case pathRow:
+			b.WriteString(pointer + render("Path: ") + m.path.View() + "\n")
+			if i == m.cursor {
+				b.WriteString(styleMuted.Render("    tab to complete, ctrl+n/ctrl+p for more suggestions") + "\n")
+			}
+			b.WriteString("\n")
+		case specialRow:
+			name := m.options.Special[r.index]
+			if r.index == 0 {
+				b.WriteString("  Special files:\n")
+			}
+			b.WriteString(pointer + render(checkbox(m.special[name])+" "+name) + "\n")
+			if r.index == len(m.options.Special)-1 {
+				b.WriteString("\n")
+			}
+		case clientRow:
+			b.WriteString(pointer + render(checkbox(m.useClient)+" 'use client'") + "\n\n")
+		case templateRow:
+			b.WriteString(pointer + render(fmt.Sprintf("Template: ‹ %s ›", m.options.Templates[m.template])) + "\n\n")
+		case confirmRow:
+			b.WriteString(pointer + render("[ Create ]") + "\n")
+		}
+	}
+	b.WriteString("\n" + styleMuted.Render("↑/↓ move • space toggle • ←/→ template • enter create • esc cancel"))
+
+	formWidth := m.width * 2 / 5
+	form := lipgloss.NewStyle().Width(formWidth).Render(b.String())
+
+	preview := "Type a path to preview the page."
+	if m.options.Preview != nil && m.Choices().Path != "" {
+		preview = m.options.Preview(m.Choices())
+	}
+	previewWidth := m.width - formWidth - 8
+	previewHeight := m.height - 4
+	if previewWidth < 10 {
+		previewWidth = 10
+	}
+	if previewHeight < 3 {
+		previewHeight = 3
+	}
+	preview = clip(preview, previewWidth-4, previewHeight-2)
+
+	return styleDoc.Render(lipgloss.JoinHorizontal(lipgloss.Top, form, stylePreview.Width(previewWidth).Render(preview)))
+}
+
+func checkbox(checked bool) string {
+	if checked {
+		return "[x]"
+	}
+	return "[ ]"
+}
+
+// clip truncates text to fit the given number of columns and lines.
+func clip(text string, width, height int) string {
+	lines := strings.Split(text, "\n")
+	if len(lines) > height {
+		lines = append(lines[:height-1], "…")
+	}
+	for i, line := range lines {
+		if runes := []rune(line); len(runes) > width {
+			lines[i] = string(runes[:width-1]) + "…"
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func New(options Options) Model {
+	path := textinput.New()
+	path.Placeholder = "blog/[slug]"
+	path.ShowSuggestions = true
+	path.SetSuggestions(options.Folders)
+	path.KeyMap.AcceptSuggestion = key.NewBinding(key.WithKeys("tab"))
+	path.KeyMap.NextSuggestion = key.NewBinding(key.WithKeys("ctrl+n"))
+	path.KeyMap.PrevSuggestion = key.NewBinding(key.WithKeys("ctrl+p"))
+	path.CompletionStyle = styleMuted
+	path.Focus()
+
+	rows := []row{{kind: pathRow}}
+	for i := range options.Special {
+		rows = append(rows, row{kind: specialRow, index: i})
+	}
+	if options.AllowClient {
+		rows = append(rows, row{kind: clientRow})
+	}
+	if len(options.Templates) > 1 {
+		rows = append(rows, row{kind: templateRow})
+	}
+	rows = append(rows, row{kind: confirmRow})
+
+	return Model{
+		options: options,
+		path:    path,
+		special: make(map[string]bool),
+		rows:    rows,
+	}
+}
+
+// WithUseClient presets the 'use client' toggle.
+func (m Model) WithUseClient(useClient bool) Model {
+	m.useClient = useClient
+	return m
+}
@@ -0,0 +1,49 @@
+package wizardui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func send(m Model, keys ...tea.KeyMsg) Model {
+	for _, k := range keys {
+		updated, _ := m.Update(k)
+		m = updated.(Model)
+	}
+	return m
+}
+
+func TestWizardFlow(t *testing.T) {
+	m := New(Options{
+		Folders:     []string{"blog/", "blog/[slug]/"},
+		Special:     []string{"layout", "loading"},
+		AllowClient: true,
+		Templates:   []string{"page", "landing"},
+	})
+
+	// Complete "bl" to "blog/" and finish the path
+	m = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("bl")}, tea.KeyMsg{Type: tea.KeyTab})
+	m = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("archive")})
+
+	// Tick "loading", toggle 'use client' and pick the second template
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	m = send(m, down, down, tea.KeyMsg{Type: tea.KeySpace}, down, tea.KeyMsg{Type: tea.KeySpace}, down, tea.KeyMsg{Type: tea.KeyRight})
+
+	// Nothing is confirmed before reaching the create button
+	_, confirmed := m.Result()
+	assert.False(t, confirmed)
+
+	m = send(m, down, tea.KeyMsg{Type: tea.KeyEnter})
+	choices, confirmed := m.Result()
+	assert.True(t, confirmed)
+	assert.Equal(t, Choices{Path: "blog/archive", Special: []string{"loading"}, UseClient: true, Template: "landing"}, choices)
+}
+
+func TestWizardCancel(t *testing.T) {
+	m := New(Options{Templates: []string{"page"}})
+	m = send(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("about")}, tea.KeyMsg{Type: tea.KeyEsc})
+	_, confirmed := m.Result()
+	assert.False(t, confirmed)
+}
@@ -3,8 +3,13 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/constants"
+	"github.com/bllakcn/nextjs-routing-helper-cli/cmd/routes"
 	treeui "github.com/bllakcn/nextjs-routing-helper-cli/cmd/ui/tree"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/savannahostrowski/tree-bubble"
@@ -15,8 +20,25 @@ import (
 var viewCmd = &cobra.Command{
 	Use:   "view",
 	Short: "Visualizes the routes in your Next.js project.",
-	Long:  `Scans the app/pages directory and prints out a tree of all available routes.`,
+	Long: `Scans the app/pages directory and prints out a tree of all available routes.
+
+The tree doubles as a route management console:
+- 'a' adds a child route under the selected node.
+- 'd' deletes the selected route after confirmation.
+- 'r' renames or moves the selected route, rewriting links to it.
+- 'o' opens the selected route's file in $EDITOR.
+
+Press '/' to fuzzy filter the tree by route and file paths; 'n'/'N' jump between matches.
+Predicates like 'kind:api' or 'dynamic:true' filter on route metadata.
+
+On wide terminals a side pane shows the selected route's URL pattern, params, file, layouts,
+client/server status, metadata and segment config, and a preview of the file.
+
+With --watch the tree is rebuilt whenever files are added, removed or renamed.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		watchFlag, _ := cmd.Flags().GetBool("watch")
+		intervalFlag, _ := cmd.Flags().GetDuration("interval")
+
 		// Load config
 		config, err := constants.LoadConfig()
 		if err != nil {
@@ -24,17 +46,13 @@ var viewCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		// Determine the router type
-		startPath := "pages"
-		if config.Router == constants.AppRouter {
-			startPath = "app"
-		}
-
 		// Create the nodes
-		nodeTree := treeui.BuildRouteTree(AppFs, startPath)
-		//
+		snapshot := treeui.Load(AppFs, config)
 
-		m := treeui.New([]tree.Node{nodeTree})
+		m := treeui.New([]tree.Node{snapshot.Root}).WithRoutes(snapshot.Routes).WithDetails(AppFs, config).WithActions(viewActions(config))
+		if watchFlag {
+			m = m.WithWatch(AppFs, config, intervalFlag)
+		}
 		p := tea.NewProgram(m, tea.WithAltScreen())
 
 		if _, err := p.Run(); err != nil {
@@ -44,6 +62,79 @@ var viewCmd = &cobra.Command{
 	},
 }
 
+// viewActions wires the view's key bindings to the same operations as the add, rm and mv commands.
+func viewActions(config *constants.Config) treeui.Actions {
+	lookup := func(path string) (*routes.Route, []routes.Route, error) {
+		found, err := routes.Scan(AppFs, config)
+		if err != nil {
+			return nil, nil, err
+		}
+		route := findRoute(found, config, path)
+		if route == nil {
+			return nil, nil, fmt.Errorf("no page found for '/%s'", path)
+		}
+		return route, found, nil
+	}
+
+	return treeui.Actions{
+		Add: func(parent, name string) error {
+			pageNameInput := filepath.ToSlash(filepath.Join(parent, name))
+			targetPath, pageComponentName, err := determinePathAndComponent(pageNameInput, config)
+			if err != nil {
+				return err
+			}
+			data := newPageData(pageNameInput, pageComponentName, config, false)
+			content, err := generateFileContent("page", data)
+			if err != nil {
+				return err
+			}
+			stylesheets, err := pageStylesheet(AppFs, targetPath, data)
+			if err != nil {
+				return err
+			}
+			for _, file := range append([]GeneratedFile{{Path: targetPath, Content: content}}, stylesheets...) {
+				if err := createPageFile(AppFs, file.Path, file.Content); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Delete: func(path string) error {
+			route, _, err := lookup(path)
+			if err != nil {
+				return err
+			}
+			_, err = removeRoute(AppFs, config, *route, false)
+			return err
+		},
+		Rename: func(path, newPath string) error {
+			route, found, err := lookup(path)
+			if err != nil {
+				return err
+			}
+			_, err = moveRoute(AppFs, config, found, *route, newPath)
+			return err
+		},
+		Open: func(path string) (*exec.Cmd, error) {
+			route, _, err := lookup(path)
+			if err != nil {
+				return nil, err
+			}
+			// $EDITOR may carry arguments, e.g. "code --wait"
+			editor := strings.Fields(os.Getenv("EDITOR"))
+			if len(editor) == 0 {
+				return nil, fmt.Errorf("$EDITOR is not set")
+			}
+			return exec.Command(editor[0], append(editor[1:], route.File)...), nil
+		},
+		Reload: func() treeui.Snapshot {
+			return treeui.Load(AppFs, config)
+		},
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(viewCmd)
+	viewCmd.Flags().Bool("watch", false, "Rebuild the tree when route files are added, removed or renamed")
+	viewCmd.Flags().Duration("interval", time.Second, "How often to poll for changes with --watch")
 }